@@ -0,0 +1,164 @@
+// Package admin provides an [http.Handler] for inspecting and replacing
+// the filter of a running [slogic.Handler], and [DebugHandler] middleware
+// for logging individual requests more verbosely.
+//
+// The handler grants control over what gets logged,
+// so it should only be served behind appropriate authentication.
+package admin // import "go.luke.ph/slogic/admin"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+// maxBodySize limits the size of the filters accepted by a [Handler].
+const maxBodySize = 1 << 20
+
+// NewHandler constructs a [*Handler] that serves the filter of the given handler.
+func NewHandler(handler *slogic.Handler) *Handler {
+	return &Handler{
+		handler:   handler,
+		afterFunc: time.AfterFunc,
+		now:       time.Now,
+	}
+}
+
+// A Handler implements the [http.Handler] interface, serving the filter of a [slogic.Handler]:
+//
+//   - GET responds with the current filter as a [Status].
+//   - PUT replaces the filter, and responds with the new filter as a [Status].
+//     The request body is either a filter expression ([filter.Parse]),
+//     or a JSON filter configuration ([filter.FromJSON]) if its Content-Type is "application/json".
+//     If the request's "ttl" query parameter holds a duration, such as "15m",
+//     the previous filter is restored once it elapses.
+//
+// Invalid filters are rejected with a 400 Bad Request response, leaving the current filter in place.
+type Handler struct {
+	handler   *slogic.Handler
+	afterFunc func(time.Duration, func()) *time.Timer
+	now       func() time.Time
+
+	mu       sync.Mutex
+	revert   *time.Timer
+	revertAt time.Time
+	previous slogic.Filter
+}
+
+// A Status describes the current filter of a [slogic.Handler].
+type Status struct {
+	// Filter is the filter's representation per [slogic.Filter.String].
+	Filter string `json:"filter"`
+
+	// Config is the filter's configuration per [filter.ConfigOf],
+	// or nil if the filter cannot be described as such.
+	Config *filter.Config `json:"config,omitempty"`
+
+	// RevertAt is the time at which the previous filter will be restored,
+	// if the filter was set with a TTL.
+	RevertAt *time.Time `json:"revertAt,omitempty"`
+}
+
+// ServeHTTP implements the [http.Handler] ServeHTTP interface method.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.writeStatus(w)
+	case http.MethodPut:
+		h.put(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) put(w http.ResponseWriter, r *http.Request) {
+	var ttl time.Duration
+	if s := r.URL.Query().Get("ttl"); s != "" {
+		var err error
+		if ttl, err = time.ParseDuration(s); err != nil || ttl <= 0 {
+			http.Error(w, fmt.Sprintf("invalid ttl %q", s), http.StatusBadRequest)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var f slogic.Filter
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		f, err = filter.FromJSON(body)
+	} else {
+		f, err = filter.Parse(strings.TrimSpace(string(body)))
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.set(f, ttl)
+	h.writeStatus(w)
+}
+
+// set replaces the handler's filter, scheduling the previous filter to be restored
+// after the given TTL, if non-zero.
+func (h *Handler) set(f slogic.Filter, ttl time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.revert != nil {
+		h.revert.Stop()
+		h.revert = nil
+	} else {
+		h.previous = h.handler.Filter()
+	}
+
+	h.handler.SetFilter(f)
+	if ttl == 0 {
+		h.previous = nil
+		return
+	}
+
+	var timer *time.Timer
+	timer = h.afterFunc(ttl, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.revert != timer {
+			return // Superseded by a subsequent PUT...
+		}
+		h.handler.SetFilter(h.previous)
+		h.revert, h.previous = nil, nil
+	})
+	h.revert = timer
+	h.revertAt = h.now().Add(ttl)
+}
+
+func (h *Handler) writeStatus(w http.ResponseWriter) {
+	h.mu.Lock()
+	f := h.handler.Filter()
+	status := Status{Filter: f.String()}
+	if h.revert != nil {
+		revertAt := h.revertAt
+		status.RevertAt = &revertAt
+	}
+	h.mu.Unlock()
+
+	if c, err := filter.ConfigOf(f); err == nil {
+		status.Config = &c
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := slogic.NewHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		filter.IfLevelAtMost(slog.LevelInfo),
+	)
+	logger := slog.New(h)
+
+	var revert func()
+	a := NewHandler(h)
+	a.afterFunc = func(d time.Duration, f func()) *time.Timer {
+		revert = f
+		return time.NewTimer(d)
+	}
+	a.now = func() time.Time {
+		return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	status := serve(t, a, http.MethodGet, "/", "", "", http.StatusOK)
+	if want := "IfLevelAtMost(INFO)"; status.Filter != want {
+		t.Errorf("got: %q, want: %q", status.Filter, want)
+	}
+	if status.Config == nil || status.Config.IfLevelAtMost == nil {
+		t.Errorf("got: %+v, want: ifLevelAtMost config", status.Config)
+	}
+
+	status = serve(t, a, http.MethodPut, "/?ttl=10m", "", "level < DEBUG", http.StatusOK)
+	if want := "Not(IfLevelAtLeast(DEBUG))"; status.Filter != want {
+		t.Errorf("got: %q, want: %q", status.Filter, want)
+	}
+	if want := time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC); status.RevertAt == nil || !status.RevertAt.Equal(want) {
+		t.Errorf("got: %v, want: %v", status.RevertAt, want)
+	}
+	logger.Debug("debugging")
+	if !strings.Contains(buf.String(), "msg=debugging") {
+		t.Errorf("got: %q, want: msg=debugging", buf.String())
+	}
+
+	status = serve(t, a, http.MethodPut, "/?ttl=5m", "application/json", `{"ifLevelAtMost": "WARN"}`, http.StatusOK)
+	if want := "IfLevelAtMost(WARN)"; status.Filter != want {
+		t.Errorf("got: %q, want: %q", status.Filter, want)
+	}
+
+	// Restores the filter in place before the first temporary filter...
+	revert()
+	status = serve(t, a, http.MethodGet, "/", "", "", http.StatusOK)
+	if want := "IfLevelAtMost(INFO)"; status.Filter != want {
+		t.Errorf("got: %q, want: %q", status.Filter, want)
+	}
+	if status.RevertAt != nil {
+		t.Errorf("got: %v, want: nil", status.RevertAt)
+	}
+}
+
+func TestHandlerInvalid(t *testing.T) {
+	h := slogic.NewHandler(slog.DiscardHandler, filter.IfLevelAtMost(slog.LevelInfo))
+	a := NewHandler(h)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		want        int
+	}{
+		{name: "expression", method: http.MethodPut, target: "/", body: "level >=", want: http.StatusBadRequest},
+		{name: "json", method: http.MethodPut, target: "/", contentType: "application/json", body: `{"level": 1}`, want: http.StatusBadRequest},
+		{name: "ttl", method: http.MethodPut, target: "/?ttl=soon", body: "level >= WARN", want: http.StatusBadRequest},
+		{name: "method", method: http.MethodPost, target: "/", body: "level >= WARN", want: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			a.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("got: %d, want: %d", w.Code, tt.want)
+			}
+		})
+	}
+
+	if got, want := h.Filter().String(), "IfLevelAtMost(INFO)"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("got: enabled, want: disabled")
+	}
+}
+
+func serve(t *testing.T, h http.Handler, method, target, contentType, body string, code int) Status {
+	t.Helper()
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	data, _ := io.ReadAll(w.Body)
+	if w.Code != code {
+		t.Fatalf("got: %d %s, want: %d", w.Code, data, code)
+	}
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatal(err)
+	}
+	return status
+}
+
+func TestHandlerDescribedFilter(t *testing.T) {
+	// A custom filter may be described as one of the filter package's...
+	custom := slogic.Describe(func(context.Context, slog.Record) bool { return false }, slogic.Description{Name: "IfLevelEquals"})
+	h := slogic.NewHandler(slog.NewTextHandler(io.Discard, nil), custom)
+
+	status := serve(t, NewHandler(h), http.MethodGet, "/", "", "", http.StatusOK)
+	if want := "IfLevelEquals()"; status.Filter != want {
+		t.Errorf("got: %q, want: %q", status.Filter, want)
+	}
+	if status.Config != nil {
+		t.Errorf("got: %+v, want: nil", status.Config)
+	}
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+// DefaultDebugHeader is the default of [DebugOptions.Header].
+const DefaultDebugHeader = "X-Debug-Log"
+
+// DebugOptions are options for a [DebugHandler].
+type DebugOptions struct {
+	// Header is the name of the request header that enables debug logging.
+	// If empty, it defaults to [DefaultDebugHeader].
+	Header string
+
+	// Key, if non-empty, is the HMAC-SHA256 key with which the header is signed, per [SignDebugHeader].
+	// Headers with a valid signature that has not expired are honored.
+	// An empty key, e.g. as read from an unset environment variable, honors no signatures.
+	Key []byte
+
+	// Allow, if non-nil, reports whether the header of the given request is honored
+	// without a valid signature, e.g. as the request is from an allowlisted network.
+	Allow func(*http.Request) bool
+}
+
+// NewDebugHandler constructs a [*DebugHandler] that wraps the given handler.
+// A nil opts is equivalent to the zero [DebugOptions].
+func NewDebugHandler(next http.Handler, opts *DebugOptions) *DebugHandler {
+	h := &DebugHandler{
+		next:   next,
+		header: DefaultDebugHeader,
+		now:    time.Now,
+	}
+	if opts != nil {
+		if opts.Header != "" {
+			h.header = opts.Header
+		}
+		h.key = opts.Key
+		h.allow = opts.Allow
+	}
+	return h
+}
+
+// A DebugHandler implements the [http.Handler] interface, as middleware that enables
+// more verbose logging for individual requests, e.g. to reproduce an issue in production.
+//
+// If a request has the header per [DebugOptions.Header], such as "X-Debug-Log: level=debug",
+// and the header is either signed per [DebugOptions.Key], or allowed per [DebugOptions.Allow],
+// the request's context carries the given level per [slogic.WithMinimumLevel], such that
+// a [slogic.Handler] keeps the records with at least the given level, unless its filter would filter
+// them out regardless of their level. A level at or above the filter's minimum level has no effect.
+// Otherwise, the header is ignored; if neither Key nor Allow are set, it is always ignored.
+//
+// Only the [slogic.Handler]'s filter is relaxed, so the handler that it wraps
+// must itself be enabled for the given level, e.g. via [slog.HandlerOptions.Level],
+// leaving the [slogic.Handler]'s filter to determine the minimum level of other requests.
+//
+// The header's value is a comma-separated list of parameters:
+//
+//   - level: the minimum level of the records to keep, per [slog.Level.UnmarshalText].
+//   - expires: the Unix time after which the header is ignored. Required if signed.
+//   - sig: the signature, per [SignDebugHeader]. Must be the last parameter.
+type DebugHandler struct {
+	next   http.Handler
+	header string
+	key    []byte
+	allow  func(*http.Request) bool
+	now    func() time.Time
+}
+
+// ServeHTTP implements the [http.Handler] ServeHTTP interface method.
+func (h *DebugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if value := r.Header.Get(h.header); value != "" {
+		if level, ok := h.level(r, value); ok {
+			ctx := slogic.WithMinimumLevel(r.Context(), level)
+			r = r.WithContext(ctx)
+		}
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// level returns the level of the given header value,
+// provided the header is valid, and signed or allowed.
+func (h *DebugHandler) level(r *http.Request, value string) (slog.Level, bool) {
+	var level slog.Level
+	var hasLevel, hasExpires bool
+	payload, sig, hasSig := strings.Cut(value, ",sig=")
+	for param := range strings.SplitSeq(payload, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch k {
+		case "level":
+			if err := level.UnmarshalText([]byte(v)); err != nil {
+				return 0, false
+			}
+			hasLevel = true
+		case "expires":
+			expires, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || h.now().After(time.Unix(expires, 0)) {
+				return 0, false
+			}
+			hasExpires = true
+		default:
+			return 0, false
+		}
+	}
+	if !hasLevel {
+		return 0, false
+	}
+	if hasSig && hasExpires && len(h.key) > 0 && hmac.Equal([]byte(sig), []byte(sign(h.key, payload))) {
+		return level, true
+	}
+	return level, h.allow != nil && h.allow(r)
+}
+
+// SignDebugHeader returns a value for the header of a [DebugHandler] that enables logging
+// of records with at least the given level until the given expiry,
+// signed with the given key per [DebugOptions.Key].
+func SignDebugHeader(key []byte, level slog.Level, expires time.Time) string {
+	payload := fmt.Sprintf("level=%s,expires=%d", level, expires.Unix())
+	return payload + ",sig=" + sign(key, payload)
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of the given payload.
+func sign(key []byte, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,188 @@
+package admin
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func TestDebugHandler(t *testing.T) {
+	key := []byte("secret")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		allow  bool
+		want   bool
+	}{
+		{
+			name:   "none",
+			header: "",
+			want:   false,
+		},
+		{
+			name:   "signed",
+			header: SignDebugHeader(key, slog.LevelDebug, now.Add(time.Hour)),
+			want:   true,
+		},
+		{
+			name:   "signed with another key",
+			header: SignDebugHeader([]byte("guess"), slog.LevelDebug, now.Add(time.Hour)),
+			want:   false,
+		},
+		{
+			name:   "expired",
+			header: SignDebugHeader(key, slog.LevelDebug, now.Add(-time.Second)),
+			want:   false,
+		},
+		{
+			name:   "tampered",
+			header: strings.Replace(SignDebugHeader(key, slog.LevelInfo, now.Add(time.Hour)), "INFO", "DEBUG", 1),
+			want:   false,
+		},
+		{
+			name:   "unsigned",
+			header: "level=debug",
+			want:   false,
+		},
+		{
+			name:   "allowed",
+			header: "level=debug",
+			allow:  true,
+			want:   true,
+		},
+		{
+			name:   "allowed but expired",
+			header: "level=debug, expires=0",
+			allow:  true,
+			want:   false,
+		},
+		{
+			name:   "allowed but invalid",
+			header: "level=verbose",
+			allow:  true,
+			want:   false,
+		},
+		{
+			name:   "allowed but unknown parameter",
+			header: "level=debug,tenant=acme",
+			allow:  true,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slogic.NewHandler(
+				slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+				filter.IfLevelAtMost(slog.LevelInfo),
+			))
+
+			h := NewDebugHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger.DebugContext(r.Context(), "debug")
+			}), &DebugOptions{
+				Key:   key,
+				Allow: func(*http.Request) bool { return tt.allow },
+			})
+			h.now = func() time.Time { return now }
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(DefaultDebugHeader, tt.header)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got := strings.Contains(buf.String(), "msg=debug"); got != tt.want {
+				t.Errorf("got: %q, want: %v", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDebugHandlerEmptyKey(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, key := range [][]byte{nil, {}} {
+		var buf bytes.Buffer
+		logger := slog.New(slogic.NewHandler(
+			slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			filter.IfLevelAtMost(slog.LevelInfo),
+		))
+
+		h := NewDebugHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.DebugContext(r.Context(), "debug")
+		}), &DebugOptions{Key: key})
+		h.now = func() time.Time { return now }
+
+		// A header signed with an empty key must not be honored, however the key was read...
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(DefaultDebugHeader, SignDebugHeader(nil, slog.LevelDebug, now.Add(time.Hour)))
+		h.ServeHTTP(httptest.NewRecorder(), r)
+
+		if buf.Len() > 0 {
+			t.Errorf("key %#v: got: %q, want: none", key, buf.String())
+		}
+	}
+}
+
+func TestDebugHandlerMinimumLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "debug",
+			header: "level=debug",
+			want:   "msg=debug\nmsg=warn\n",
+		},
+		{
+			name:   "error",
+			header: "level=error",
+			want:   "msg=warn\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slogic.NewHandler(
+				slog.NewTextHandler(&buf, &slog.HandlerOptions{
+					Level: slog.LevelDebug,
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+							return slog.Attr{}
+						}
+						return a
+					},
+				}),
+				slogic.Or(filter.IfLevelAtMost(slog.LevelInfo), filter.IfMessageContains("health")),
+			))
+
+			h := NewDebugHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger.DebugContext(r.Context(), "debug")
+				logger.DebugContext(r.Context(), "debug health")
+				logger.WarnContext(r.Context(), "warn")
+				logger.WarnContext(r.Context(), "warn health")
+			}), &DebugOptions{
+				Allow: func(*http.Request) bool { return true },
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(DefaultDebugHeader, tt.header)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
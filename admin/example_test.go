@@ -0,0 +1,45 @@
+package admin_test
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/admin"
+	"go.luke.ph/slogic/filter"
+)
+
+func Example() {
+	handler := slogic.NewHandler(
+		slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		filter.IfLevelAtMost(slog.LevelDebug),
+	)
+	slog.SetDefault(slog.New(handler))
+
+	// E.g. to enable DEBUG logs for 15 minutes:
+	//
+	//	curl -X PUT -d 'level < DEBUG' 'localhost:6060/debug/slogic?ttl=15m'
+	mux := http.NewServeMux()
+	mux.Handle("/debug/slogic", admin.NewHandler(handler))
+	_ = http.ListenAndServe("localhost:6060", mux)
+}
+
+func ExampleNewDebugHandler() {
+	handler := slogic.NewHandler(
+		slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		filter.IfLevelAtMost(slog.LevelInfo),
+	)
+	slog.SetDefault(slog.New(handler))
+
+	key := []byte(os.Getenv("DEBUG_LOG_KEY"))
+
+	// E.g. to issue a header that enables DEBUG logs for an hour:
+	//
+	//	admin.SignDebugHeader(key, slog.LevelDebug, time.Now().Add(time.Hour))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
+		slog.DebugContext(r.Context(), "Received request", "method", r.Method, "path", r.URL.Path)
+	})
+	_ = http.ListenAndServe("localhost:8080", admin.NewDebugHandler(mux, &admin.DebugOptions{Key: key}))
+}
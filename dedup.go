@@ -0,0 +1,185 @@
+package slogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var _ slog.Handler = (*DedupHandler)(nil)
+
+// RepeatedKey is the key of the attribute that a [DedupHandler] adds to each summary record,
+// holding the message of the records it suppressed.
+const RepeatedKey = "repeated"
+
+// NewDedupHandler constructs a [*DedupHandler] that wraps the given handler,
+// suppressing records that repeat within the given window.
+//
+// Records are repeats if they have the same level and message, are handled by the same handler,
+// and the given key function, if non-nil, returns the same key for them,
+// e.g. [go.luke.ph/slogic/filter.ByAttr] to also compare the value of an attribute.
+// The key function can use [Attrs] like a [Filter].
+//
+// As handlers derived via WithAttrs and WithGroup are distinct, records logged via
+// e.g. logger.With("tenant", "a") and logger.With("tenant", "b") are never repeats of each other,
+// so each summary carries the same attributes as the records it summarizes.
+// Conversely, records logged via loggers derived anew for each call are never repeats either.
+func NewDedupHandler(handler slog.Handler, window time.Duration, key func(context.Context, slog.Record) string) *DedupHandler {
+	return &DedupHandler{
+		handler: handler,
+		state: &dedupState{
+			window:    window,
+			key:       key,
+			afterFunc: time.AfterFunc,
+			now:       time.Now,
+			seen:      make(map[dedupKey]*dedupEntry),
+		},
+	}
+}
+
+// A DedupHandler implements the [slog.Handler] interface.
+//
+// It passes the first of any repeated records to the wrapped handler and suppresses the repeats
+// that follow within the window. When the window closes, if any records were suppressed,
+// it passes a summary record, such as "last message repeated 312 times",
+// to the wrapped handler, with the level of the suppressed records
+// and an attribute with the key [RepeatedKey] holding their message.
+type DedupHandler struct {
+	handler slog.Handler
+	state   *dedupState
+	scope
+}
+
+// dedupState holds the records seen by a [DedupHandler] within their windows,
+// shared by the handlers derived from it via WithAttrs and WithGroup.
+type dedupState struct {
+	window    time.Duration
+	key       func(context.Context, slog.Record) string
+	afterFunc func(time.Duration, func()) *time.Timer
+	now       func() time.Time
+
+	mu   sync.Mutex
+	seen map[dedupKey]*dedupEntry
+}
+
+type dedupKey struct {
+	handler *DedupHandler
+	level   slog.Level
+	message string
+	key     string
+}
+
+// dedupEntry holds the first record of a window,
+// along with the context and handler with which to summarize its repeats.
+type dedupEntry struct {
+	bufferedRecord
+	repeats int
+	timer   *time.Timer
+}
+
+// Enabled implements the [slog.Handler] Enabled interface method.
+// It calls the wrapped handler's Enabled method.
+func (h *DedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements the [slog.Handler] Handle interface method.
+// It calls the wrapped handler's Handle method only if the record does not repeat
+// a record within its window.
+func (h *DedupHandler) Handle(ctx context.Context, r slog.Record) error {
+	k := dedupKey{handler: h, level: r.Level, message: r.Message}
+	if h.state.key != nil {
+		k.key = h.state.key(h.ctx(ctx), r)
+	}
+
+	s := h.state
+	s.mu.Lock()
+	if e, ok := s.seen[k]; ok {
+		e.repeats++
+		s.mu.Unlock()
+		return nil
+	}
+	e := &dedupEntry{
+		bufferedRecord: newBufferedRecord(ctx, h.handler, slog.NewRecord(r.Time, r.Level, r.Message, r.PC)),
+	}
+	e.timer = s.afterFunc(s.window, func() {
+		_ = s.expire(k, e)
+	})
+	s.seen[k] = e
+	s.mu.Unlock()
+
+	return h.handler.Handle(ctx, r)
+}
+
+// Flush closes every open window, passing the summaries of any suppressed records
+// to the wrapped handler without waiting for the windows to elapse,
+// e.g. before the program exits.
+// It returns the errors of the wrapped handler's Handle calls joined via [errors.Join].
+func (h *DedupHandler) Flush() error {
+	s := h.state
+	s.mu.Lock()
+	entries := make(map[dedupKey]*dedupEntry, len(s.seen))
+	for k, e := range s.seen {
+		e.timer.Stop()
+		entries[k] = e
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for k, e := range entries {
+		if err := s.expire(k, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expire closes the window of the given entry, passing its summary to its handler
+// if any records were suppressed.
+func (s *dedupState) expire(k dedupKey, e *dedupEntry) error {
+	s.mu.Lock()
+	if s.seen[k] != e {
+		// Already expired...
+		s.mu.Unlock()
+		return nil
+	}
+	delete(s.seen, k)
+	repeats := e.repeats
+	s.mu.Unlock()
+
+	if repeats == 0 || !e.handler.Enabled(e.ctx, e.record.Level) {
+		return nil
+	}
+	summary := slog.NewRecord(s.now(), e.record.Level, fmt.Sprintf("last message repeated %d times", repeats), e.record.PC)
+	summary.AddAttrs(slog.String(RepeatedKey, e.record.Message))
+	return e.handler.Handle(e.ctx, summary)
+}
+
+// WithAttrs implements the [slog.Handler] WithAttrs interface method.
+// It calls the wrapped handler's WithAttrs method,
+// and retains the attributes so that they are visible to the key function via [Attrs].
+func (h *DedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.handler = h.handler.WithAttrs(attrs)
+	h2.scope = h.with(groupOrAttrs{attrs: attrs})
+	return &h2
+}
+
+// WithGroup implements the [slog.Handler] WithGroup interface method.
+// It calls the wrapped handler's WithGroup method,
+// and retains the group so that it is visible to the key function via [Attrs].
+func (h *DedupHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.handler = h.handler.WithGroup(name)
+	h2.scope = h.with(groupOrAttrs{group: name})
+	return &h2
+}
@@ -0,0 +1,139 @@
+package slogic
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+func TestDedupHandlerHandler(t *testing.T) {
+	var buf bytes.Buffer
+	var n atomic.Int64
+	// Distinguishes every record, so that none are suppressed...
+	h := NewDedupHandler(slog.NewJSONHandler(&buf, nil), time.Minute, func(context.Context, slog.Record) string {
+		return strconv.FormatInt(n.Add(1), 10)
+	})
+
+	err := slogtest.TestHandler(h, jsonResults(t, &buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDedupHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), time.Minute, func(ctx context.Context, r slog.Record) string {
+		for groups, attr := range Attrs(ctx, r) {
+			if len(groups) == 0 && attr.Key == "host" {
+				return attr.Value.String()
+			}
+		}
+		return ""
+	})
+	var expire []func()
+	h.state.afterFunc = func(d time.Duration, f func()) *time.Timer {
+		if d != time.Minute {
+			t.Errorf("got: %v, want: %v", d, time.Minute)
+		}
+		expire = append(expire, f)
+		return time.NewTimer(d)
+	}
+	h.state.now = func() time.Time {
+		return time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)
+	}
+
+	logger := slog.New(h)
+	for i := range 3 {
+		logger.Warn("Retrying", "attempt", i)
+	}
+	logger.With("host", "db-1").Warn("Retrying", "attempt", 0)
+	logger.Error("Retrying", "attempt", 0)
+
+	// Closes the windows of the first and second records...
+	expire[0]()
+	expire[1]()
+	logger.Warn("Retrying", "attempt", 3)
+
+	want := `level=WARN msg=Retrying attempt=0
+level=WARN msg=Retrying host=db-1 attempt=0
+level=ERROR msg=Retrying attempt=0
+time=2025-01-01T00:01:00.000Z level=WARN msg="last message repeated 2 times" repeated=Retrying
+level=WARN msg=Retrying attempt=3
+`
+	if got := stripTime(buf.String()); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// Closing the same window twice has no effect...
+	expire[0]()
+	if got := stripTime(buf.String()); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestDedupHandlerFlush(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), time.Hour, nil)
+	h.state.now = func() time.Time {
+		return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	logger := slog.New(h).WithGroup("g")
+	for range 3 {
+		logger.Info("Polled queue")
+	}
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("Polled queue")
+
+	want := `level=INFO msg="Polled queue"
+time=2025-01-01T00:00:00.000Z level=INFO msg="last message repeated 2 times" g.repeated="Polled queue"
+level=INFO msg="Polled queue"
+`
+	if got := stripTime(buf.String()); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestDedupHandlerScope(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewDedupHandler(slog.NewTextHandler(&buf, nil), time.Hour, nil)
+	h.state.now = func() time.Time {
+		return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	a, b := slog.New(h).With("tenant", "a"), slog.New(h).With("tenant", "b")
+	a.Info("Retrying")
+	b.Info("Retrying")
+	b.Info("Retrying")
+	if err := h.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `level=INFO msg=Retrying tenant=a
+level=INFO msg=Retrying tenant=b
+time=2025-01-01T00:00:00.000Z level=INFO msg="last message repeated 1 times" tenant=b repeated=Retrying
+`
+	if got := stripTime(buf.String()); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+// stripTime removes the times of the given text handler output,
+// other than those of summary records, which are fixed.
+func stripTime(s string) string {
+	var b bytes.Buffer
+	for line := range bytes.Lines([]byte(s)) {
+		if !bytes.Contains(line, []byte("repeated=")) && bytes.HasPrefix(line, []byte("time=")) {
+			_, line, _ = bytes.Cut(line, []byte(" "))
+		}
+		b.Write(line)
+	}
+	return b.String()
+}
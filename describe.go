@@ -0,0 +1,264 @@
+package slogic
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// A Description describes how a [Filter] was constructed,
+// allowing the filter to be inspected via [Inspect].
+type Description struct {
+	// Name is the name of the function that constructed the filter, e.g. "And" or "IfLevelAtLeast".
+	Name string
+
+	// Args are the arguments, other than filters, that the filter was constructed with.
+	Args []any
+
+	// Filters are the filters that the filter was constructed with, e.g. the operands of [And].
+	Filters []Filter
+
+	// Level, if non-nil, returns the filter's result for any record with the given level.
+	// It must only be set for filters whose result depends on the record's Level alone,
+	// which allows a [Handler] to report such levels as disabled in its Enabled method.
+	Level func(slog.Level) bool
+
+	// MinimumLevel, if non-nil, returns the minimum level of records like the given one,
+	// and LowestLevel is the lowest level that it returns for any record.
+	// It must only be set for filters that return true for exactly the records below their minimum level,
+	// such as [go.luke.ph/slogic/filter.ParseDirectives], which allows a [Handler] to report
+	// the levels below LowestLevel as disabled, and [WithMinimumLevel] to relax the filter.
+	MinimumLevel func(slog.Record) slog.Level
+	LowestLevel  slog.Level
+}
+
+// Describe returns a [Filter] that behaves like the given filter,
+// and that [Inspect] reports as having the given description.
+func Describe(filter Filter, desc Description) Filter {
+	return (&described{filter: filter, desc: desc}).eval
+}
+
+// Inspect returns the description of the given filter,
+// and returns false if the filter was not constructed via [Describe].
+//
+// All of the filters provided by this package and the [go.luke.ph/slogic/filter] package
+// are constructed via [Describe].
+func Inspect(filter Filter) (Description, bool) {
+	d := describedOf(filter)
+	if d == nil {
+		return Description{}, false
+	}
+	return d.desc, true
+}
+
+// Operator returns the name of the logical operator that constructed the given filter,
+// "And", "Or" or "Not", or "" if the filter was constructed otherwise,
+// including via [Describe] with a description of the same name.
+func Operator(filter Filter) string {
+	d := describedOf(filter)
+	if d == nil {
+		return ""
+	}
+	switch d.op {
+	case opAnd:
+		return "And"
+	case opOr:
+		return "Or"
+	case opNot:
+		return "Not"
+	}
+	return ""
+}
+
+// described is a [Filter] with a [Description].
+type described struct {
+	filter Filter
+	desc   Description
+	op     op
+}
+
+// op identifies the logical operators, whose semantics are known to this package.
+type op int
+
+const (
+	opNone op = iota
+	opAnd
+	opOr
+	opNot
+)
+
+func describeOp(op op, filter Filter, desc Description) Filter {
+	return (&described{filter: filter, desc: desc, op: op}).eval
+}
+
+func (d *described) eval(ctx context.Context, r slog.Record) bool {
+	if in, ok := ctx.(*inspector); ok {
+		in.described = d
+		return false
+	}
+	return d.filter(ctx, r)
+}
+
+// describedPC is the code pointer shared by every [described] eval method value,
+// used to distinguish them from other filters, including those that merely call one.
+var describedPC = reflect.ValueOf((&described{}).eval).Pointer()
+
+// inspector is a [context.Context] that a [described] filter,
+// when called with it, records itself into rather than evaluating.
+type inspector struct {
+	context.Context
+	described *described
+}
+
+func describedOf(filter Filter) *described {
+	if filter == nil || reflect.ValueOf(filter).Pointer() != describedPC {
+		return nil
+	}
+	in := &inspector{Context: context.Background()}
+	filter(in, slog.Record{})
+	return in.described
+}
+
+// tri is a three-valued logical result.
+type tri int8
+
+const (
+	unknown tri = iota
+	isFalse
+	isTrue
+)
+
+func triOf(b bool) tri {
+	if b {
+		return isTrue
+	}
+	return isFalse
+}
+
+// levelResult returns a function that reports the result of the given filter
+// for any record with the given level, or unknown if it also depends on anything else.
+// It returns nil if the result is always unknown.
+func levelResult(filter Filter) func(slog.Level) tri {
+	d := describedOf(filter)
+	if d == nil {
+		return nil
+	}
+	if d.desc.Level != nil {
+		level := d.desc.Level
+		return func(l slog.Level) tri {
+			return triOf(level(l))
+		}
+	}
+	if d.desc.MinimumLevel != nil {
+		lowest := d.desc.LowestLevel
+		return func(l slog.Level) tri {
+			if l < lowest {
+				return isTrue
+			}
+			return unknown
+		}
+	}
+
+	var results []func(slog.Level) tri
+	known := false
+	for _, filter := range d.desc.Filters {
+		result := levelResult(filter)
+		results = append(results, result)
+		known = known || result != nil
+	}
+	if !known {
+		return nil
+	}
+
+	switch d.op {
+	case opAnd:
+		return func(l slog.Level) tri {
+			t := isTrue
+			for _, result := range results {
+				switch resultAt(result, l) {
+				case isFalse:
+					return isFalse
+				case unknown:
+					t = unknown
+				}
+			}
+			return t
+		}
+	case opOr:
+		return func(l slog.Level) tri {
+			t := isFalse
+			for _, result := range results {
+				switch resultAt(result, l) {
+				case isTrue:
+					return isTrue
+				case unknown:
+					t = unknown
+				}
+			}
+			return t
+		}
+	case opNot:
+		result := results[0]
+		return func(l slog.Level) tri {
+			switch resultAt(result, l) {
+			case isTrue:
+				return isFalse
+			case isFalse:
+				return isTrue
+			}
+			return unknown
+		}
+	}
+	return nil
+}
+
+func resultAt(result func(slog.Level) tri, l slog.Level) tri {
+	if result == nil {
+		return unknown
+	}
+	return result(l)
+}
+
+// String returns a representation of the filter's construction, e.g.
+//
+//	And(IfLevelAtMost(WARN), Not(IfAttrExists(latency_ms)))
+//
+// Filters that were not constructed via [Describe] are represented as "Filter".
+func (f Filter) String() string {
+	var b strings.Builder
+	writeFilter(&b, f)
+	return b.String()
+}
+
+func writeFilter(b *strings.Builder, f Filter) {
+	desc, ok := Inspect(f)
+	if !ok {
+		b.WriteString("Filter")
+		return
+	}
+	writeDescription(b, desc, true)
+}
+
+// writeDescription writes the given description's name and arguments,
+// followed by its filters if requested.
+func writeDescription(b *strings.Builder, desc Description, filters bool) {
+	b.WriteString(desc.Name)
+	b.WriteByte('(')
+	for i, arg := range desc.Args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprint(b, arg)
+	}
+	if filters {
+		for i, filter := range desc.Filters {
+			if i > 0 || len(desc.Args) > 0 {
+				b.WriteString(", ")
+			}
+			writeFilter(b, filter)
+		}
+	}
+	b.WriteByte(')')
+}
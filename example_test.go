@@ -1,6 +1,10 @@
 package slogic_test
 
 import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"time"
@@ -103,6 +107,229 @@ func ExampleNot() {
 	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
 }
 
+func ExampleHandler_SetFilter() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.IfLevelAtMost(slog.LevelInfo),
+	)
+
+	logger := slog.New(handler).With("component", "db")
+
+	logger.Debug("Opened connection") // Filtered
+	logger.Warn("Executed slow database query", "latency_ms", 250)
+
+	// E.g. while investigating an incident...
+	handler.SetFilter(filter.IfLevelAtMost(slog.LevelDebug - 1))
+
+	logger.Debug("Opened connection")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Executed slow database query" component=db latency_ms=250
+	// time=1970-01-01T00:00:00.000Z level=DEBUG msg="Opened connection" component=db
+}
+
+func ExampleExplain() {
+	f := slogic.Or(
+		filter.IfLevelEquals(slog.LevelError),
+		slogic.And(
+			filter.IfLevelEquals(slog.LevelWarn),
+			filter.IfAttrExists("latency_ms"),
+		),
+	)
+
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, "Executed slow database query", 0)
+	r.AddAttrs(slog.Int("latency_ms", 250))
+
+	fmt.Println(slogic.Explain(context.Background(), f, r))
+
+	// Output:
+	// Or(IfLevelEquals(ERROR)=false, And(IfLevelEquals(WARN)=true, IfAttrExists(latency_ms)=true)=true)=true
+}
+
+func ExampleHandlerOptions_explain() {
+	handler := slogic.NewHandlerWithOptions(
+		slog.NewTextHandler(os.Stdout, opts),
+		slogic.And(
+			filter.IfLevelAtMost(slog.LevelWarn),
+			slogic.Not(filter.IfAttrExists("latency_ms")),
+		),
+		&slogic.HandlerOptions{Explain: true},
+	)
+
+	logger := slog.New(handler)
+
+	logger.Info("Authenticated user", "user_id", "user_123")
+	logger.Warn("Executed slow database query", "latency_ms", 250)
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Authenticated user" user_id=user_123 slogic="And(IfLevelAtMost(WARN)=true, Not(IfAttrExists(latency_ms)=false)=true)=true"
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Executed slow database query" latency_ms=250 slogic="And(IfLevelAtMost(WARN)=true, Not(IfAttrExists(latency_ms)=true)=false)=false"
+}
+
+func ExampleHandlerOptions_dropped() {
+	handler := slogic.NewHandlerWithOptions(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.IfLevelAtMost(slog.LevelInfo),
+		&slogic.HandlerOptions{
+			// E.g. a local file that retains everything...
+			Dropped: slog.NewJSONHandler(os.Stdout, opts),
+		},
+	)
+
+	logger := slog.New(handler)
+
+	logger.Debug("Received request", "method", "GET", "path", "/api/users", "ip", "192.168.1.1")
+	logger.Warn("Executed slow database query", "query", "getUserProfile", "latency_ms", 250)
+
+	// Output:
+	// {"time":"1970-01-01T00:00:00Z","level":"DEBUG","msg":"Received request","method":"GET","path":"/api/users","ip":"192.168.1.1"}
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Executed slow database query" query=getUserProfile latency_ms=250
+}
+
+func ExampleNewRouter() {
+	handler := slogic.NewRouter(
+		slogic.FirstMatch,
+		slogic.Route{
+			// E.g. an alerting handler, for errors only...
+			Filter:  filter.IfLevelAtMost(slog.LevelWarn),
+			Handler: slog.NewJSONHandler(os.Stdout, opts),
+		},
+		slogic.Route{
+			Handler: slog.NewTextHandler(os.Stdout, opts),
+		},
+	)
+
+	logger := slog.New(handler)
+
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader")
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Authenticated user" user_id=user_123 roles=admin,reader
+	// {"time":"1970-01-01T00:00:00Z","level":"ERROR","msg":"Failed to process payment","order_id":"ORD-9876","error":"gateway_timeout"}
+}
+
+func ExampleMetrics() {
+	metrics := slogic.NewMetrics()
+	expvar.Publish("slogic", metrics)
+
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(io.Discard, opts),
+		metrics.Filter("root", slogic.Or(
+			metrics.Filter("payment", filter.IfMessageContains("payment")),
+			metrics.Filter("admin", filter.IfAttrContains("roles", "admin")),
+		)),
+	)
+
+	logger := slog.New(handler)
+
+	logger.Debug("Received request", "method", "GET", "path", "/api/users", "ip", "192.168.1.1")
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader") // Filtered
+	logger.Warn("Executed slow database query", "query", "getUserProfile", "latency_ms", 250)
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout") // Filtered
+
+	for _, name := range []string{"root", "payment", "admin"} {
+		stats := metrics.Snapshot()[name]
+		fmt.Printf("%s: %d/%d\n", name, stats.Matches, stats.Evaluations)
+	}
+
+	// Output:
+	// root: 2/4
+	// payment: 1/4
+	// admin: 1/3
+}
+
+func ExampleNewDedupHandler() {
+	handler := slogic.NewDedupHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		time.Minute,
+		filter.ByAttr("host"),
+	)
+
+	logger := slog.New(handler)
+
+	for i := range 312 {
+		logger.Warn("Retrying connection", "host", "db-1", "attempt", i) // Filtered unless attempt is 0
+	}
+	logger.Warn("Retrying connection", "host", "db-2", "attempt", 0)
+
+	// Closes the windows early, rather than waiting a minute...
+	_ = handler.Flush()
+
+	// Unordered output:
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Retrying connection" host=db-1 attempt=0
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Retrying connection" host=db-2 attempt=0
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="last message repeated 311 times" repeated="Retrying connection"
+}
+
+func ExampleNewFingersCrossedHandler() {
+	handler := slogic.NewFingersCrossedHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		// Holds back DEBUG logs...
+		filter.IfLevelAtMost(slog.LevelDebug),
+		// ... until an ERROR log occurs
+		filter.IfLevelAtLeast(slog.LevelError),
+		100,
+	)
+
+	logger := slog.New(handler)
+
+	logger.Debug("Received request", "method", "GET", "path", "/api/users", "ip", "192.168.1.1")
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader")
+	logger.Debug("Executed database query", "query", "getUserProfile", "latency_ms", 25)
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Authenticated user" user_id=user_123 roles=admin,reader
+	// time=1970-01-01T00:00:00.000Z level=DEBUG msg="Received request" method=GET path=/api/users ip=192.168.1.1
+	// time=1970-01-01T00:00:00.000Z level=DEBUG msg="Executed database query" query=getUserProfile latency_ms=25
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
+}
+
+func ExampleNewTailSamplingHandler() {
+	handler := slogic.NewTailSamplingHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.ByAttr("request_id"),
+		// Keeps the logs of requests that fail...
+		filter.IfLevelAtLeast(slog.LevelError),
+		nil,
+	)
+
+	for _, requestID := range []string{"req-1", "req-2"} {
+		logger := slog.New(handler).With("request_id", requestID)
+
+		logger.Info("Received request", "method", "POST", "path", "/api/payments")
+		if requestID == "req-2" {
+			logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+		}
+		handler.End(requestID)
+	}
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Received request" request_id=req-2 method=POST path=/api/payments
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" request_id=req-2 order_id=ORD-9876 error=gateway_timeout
+}
+
+func ExampleWithOverride() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.IfLevelAtMost(slog.LevelInfo),
+	)
+
+	logger := slog.New(handler)
+
+	// E.g. a request being debugged...
+	ctx := slogic.WithOverride(context.Background(), filter.IfLevelAtMost(slog.LevelDebug-1))
+
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader") // Filtered
+	logger.InfoContext(ctx, "Authenticated user", "user_id", "user_456", "roles", "reader")
+	logger.DebugContext(ctx, "Executed database query", "query", "getUserProfile", "latency_ms", 25)
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Authenticated user" user_id=user_456 roles=reader
+	// time=1970-01-01T00:00:00.000Z level=DEBUG msg="Executed database query" query=getUserProfile latency_ms=25
+}
+
 var opts = &slog.HandlerOptions{
 	Level: slog.LevelDebug,
 	// Replaces the log time with a fixed value for testable examples...
@@ -0,0 +1,98 @@
+package slogic
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ExplainKey is the key of the attribute that a [Handler] with [HandlerOptions.Explain] set
+// adds to each record, holding the record's [Explanation].
+const ExplainKey = "slogic"
+
+// An Explanation records the evaluation of a [Filter] for a given record,
+// including the evaluation of each of its operands.
+type Explanation struct {
+	// Filter is the filter's representation, per [Filter.String], excluding its operands.
+	Filter string
+
+	// Result is the filter's result.
+	Result bool
+
+	// Operands are the explanations of the operands of [And], [Or] and [Not] filters,
+	// in the order in which they were evaluated.
+	// Operands that were not evaluated because the result was already determined are omitted.
+	Operands []Explanation
+
+	// operator is true if the filter is one of [And], [Or] and [Not].
+	operator bool
+}
+
+// Explain evaluates the given filter for the given record,
+// returning an [Explanation] of the result.
+//
+// Any [Attrs] visible to the filter must already be carried by the given context,
+// as is the case for a filter evaluated by a [Handler].
+func Explain(ctx context.Context, filter Filter, r slog.Record) Explanation {
+	d := describedOf(filter)
+	if d == nil {
+		return Explanation{Filter: "Filter", Result: filter(ctx, r)}
+	}
+
+	e := Explanation{Filter: d.desc.Name, operator: true}
+	switch d.op {
+	case opAnd:
+		e.Result = true
+		for _, filter := range d.desc.Filters {
+			operand := Explain(ctx, filter, r)
+			e.Operands = append(e.Operands, operand)
+			if !operand.Result {
+				e.Result = false
+				break
+			}
+		}
+	case opOr:
+		for _, filter := range d.desc.Filters {
+			operand := Explain(ctx, filter, r)
+			e.Operands = append(e.Operands, operand)
+			if operand.Result {
+				e.Result = true
+				break
+			}
+		}
+	case opNot:
+		operand := Explain(ctx, d.desc.Filters[0], r)
+		e.Operands = []Explanation{operand}
+		e.Result = !operand.Result
+	default:
+		var b strings.Builder
+		writeDescription(&b, d.desc, false)
+		e = Explanation{Filter: b.String(), Result: filter(ctx, r)}
+	}
+	return e
+}
+
+// String returns a representation of the explanation, e.g.
+//
+//	Or(IfLevelEquals(ERROR)=false, And(IfLevelEquals(WARN)=true, IfAttrExists(latency_ms)=true)=true)=true
+func (e Explanation) String() string {
+	var b strings.Builder
+	e.write(&b)
+	return b.String()
+}
+
+func (e Explanation) write(b *strings.Builder) {
+	b.WriteString(e.Filter)
+	if e.operator {
+		b.WriteByte('(')
+		for i, operand := range e.Operands {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			operand.write(b)
+		}
+		b.WriteByte(')')
+	}
+	fmt.Fprintf(b, "=%t", e.Result)
+}
@@ -0,0 +1,88 @@
+package slogic
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	isError := Describe(
+		func(_ context.Context, r slog.Record) bool { return r.Level == slog.LevelError },
+		Description{Name: "IsLevel", Args: []any{slog.LevelError}},
+	)
+	isWarn := Describe(
+		func(_ context.Context, r slog.Record) bool { return r.Level == slog.LevelWarn },
+		Description{Name: "IsLevel", Args: []any{slog.LevelWarn}},
+	)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		level  slog.Level
+		want   string
+	}{
+		{
+			name:   "or",
+			filter: Or(isError, And(isWarn, mockFilter(true))),
+			level:  slog.LevelWarn,
+			want:   "Or(IsLevel(ERROR)=false, And(IsLevel(WARN)=true, Filter=true)=true)=true",
+		},
+		{
+			name:   "short-circuit",
+			filter: Or(isError, And(isWarn, mockFilter(true))),
+			level:  slog.LevelError,
+			want:   "Or(IsLevel(ERROR)=true)=true",
+		},
+		{
+			name:   "not",
+			filter: And(Not(isError), mockFilter(false)),
+			level:  slog.LevelInfo,
+			want:   "And(Not(IsLevel(ERROR)=false)=true, Filter=false)=false",
+		},
+		{
+			name:   "empty",
+			filter: And(),
+			level:  slog.LevelInfo,
+			want:   "And()=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := slog.NewRecord(time.Time{}, tt.level, "", 0)
+			e := Explain(context.Background(), tt.filter, r)
+			if got := e.String(); got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+			if got := tt.filter(context.Background(), r); e.Result != got {
+				t.Errorf("got: %v, want: %v", e.Result, got)
+			}
+		})
+	}
+}
+
+func TestHandlerExplain(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandlerWithOptions(
+		slog.NewTextHandler(&buf, nil),
+		Not(mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelWarn })),
+		&HandlerOptions{Explain: true},
+	)
+
+	logger := slog.New(h)
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	for _, want := range []string{
+		`msg=dropped slogic="Not(mockLevelFilter()=false)=true"`,
+		`msg=kept slogic="Not(mockLevelFilter()=true)=false"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("got: %q, want: %q", buf.String(), want)
+		}
+	}
+}
@@ -1,57 +1,306 @@
 package filter
 
 import (
+	"cmp"
 	"context"
 	"log/slog"
+	"math"
+	"math/big"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"go.luke.ph/slogic"
 )
 
 // IfAttrEquals returns a [slogic.Filter] that returns true if
-// the record's [slog.Attr] with the given key is equivalent to the given value.
+// the record's [slog.Attr] with the given key ([Attribute Paths]) is equivalent to the given value.
 func IfAttrEquals(key string, value any) slogic.Filter {
-	return ifAttr(key, func(attr slog.Attr) bool {
+	return describe("IfAttrEquals", ifAttr(key, func(attr slog.Attr) bool {
 		return attr.Value.Equal(slog.AnyValue(value))
-	})
+	}), key, value)
 }
 
 // IfAttrContains returns a [slogic.Filter] that returns true if
-// the record's [slog.Attr] with the given key contains the given substring.
+// the record's [slog.Attr] with the given key ([Attribute Paths]) contains the given substring.
 func IfAttrContains(key, substring string) slogic.Filter {
-	return ifAttr(key, func(attr slog.Attr) bool {
+	return describe("IfAttrContains", ifAttr(key, func(attr slog.Attr) bool {
 		return strings.Contains(attr.Value.String(), substring)
-	})
+	}), key, substring)
 }
 
 // IfAttrMatches returns a [slogic.Filter] that returns true if
-// the record's [slog.Attr] with the given key matches the given regular expression.
+// the record's [slog.Attr] with the given key ([Attribute Paths]) matches the given regular expression.
 func IfAttrMatches(key, pattern string) slogic.Filter {
 	re := regexp.MustCompile(pattern)
-	return ifAttr(key, func(attr slog.Attr) bool {
+	return describe("IfAttrMatches", ifAttr(key, func(attr slog.Attr) bool {
 		return re.MatchString(attr.Value.String())
-	})
+	}), key, pattern)
 }
 
 // IfAttrExists returns a [slogic.Filter] that returns true if
-// the record's [slog.Attr] with the given key exists.
+// the record's [slog.Attr] with the given key ([Attribute Paths]) exists.
 func IfAttrExists(key string) slogic.Filter {
-	return ifAttr(key, func(attr slog.Attr) bool {
+	return describe("IfAttrExists", ifAttr(key, func(attr slog.Attr) bool {
 		return true
-	})
+	}), key)
 }
 
+// IfAttr returns a [slogic.Filter] that returns true if
+// the record's [slog.Attr] with the given key ([Attribute Paths]) has a value of type T
+// for which the given predicate returns true.
+//
+// The attribute's value is resolved, per [slog.Value.Resolve], and converted to T if it holds
+// a value of that type, as returned by [slog.Value.Any], such as a string, [time.Duration], an error
+// or a custom struct. Integers and floats are additionally converted to the predeclared integer
+// and float types of any size, provided they fit, e.g. so that an int predicate accepts [slog.Int64] values.
+// If T is [slog.Value], the predicate is passed the resolved value as-is.
+// Attributes whose values cannot be converted are skipped.
+func IfAttr[T any](key string, predicate func(T) bool) slogic.Filter {
+	return describe("IfAttr["+reflect.TypeFor[T]().String()+"]", ifAttr(key, func(attr slog.Attr) bool {
+		v, ok := valueAs[T](attr.Value)
+		return ok && predicate(v)
+	}), key)
+}
+
+// valueAs converts the given resolved value to T, reporting whether it could.
+func valueAs[T any](value slog.Value) (T, bool) {
+	var t T
+	switch p := any(&t).(type) {
+	case *slog.Value:
+		*p = value
+		return t, true
+	}
+	if v, ok := value.Any().(T); ok {
+		return v, true
+	}
+
+	rv := reflect.ValueOf(&t).Elem()
+	if rv.Type().PkgPath() != "" {
+		// Named types, such as time.Duration, only hold values of their own...
+		return t, false
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch value.Kind() {
+		case slog.KindInt64:
+			if n := value.Int64(); !rv.OverflowInt(n) {
+				rv.SetInt(n)
+				return t, true
+			}
+		case slog.KindUint64:
+			if n := value.Uint64(); n <= math.MaxInt64 && !rv.OverflowInt(int64(n)) {
+				rv.SetInt(int64(n))
+				return t, true
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch value.Kind() {
+		case slog.KindInt64:
+			if n := value.Int64(); n >= 0 && !rv.OverflowUint(uint64(n)) {
+				rv.SetUint(uint64(n))
+				return t, true
+			}
+		case slog.KindUint64:
+			if n := value.Uint64(); !rv.OverflowUint(n) {
+				rv.SetUint(n)
+				return t, true
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		if value.Kind() == slog.KindFloat64 {
+			if f := value.Float64(); !rv.OverflowFloat(f) {
+				rv.SetFloat(f)
+				return t, true
+			}
+		}
+	}
+	return t, false
+}
+
+// IfAttrGreaterThan returns a [slogic.Filter] that returns true if
+// the record's [slog.Attr] with the given key ([Attribute Paths]) is greater than the given value.
+//
+// Numeric values, i.e. those of kind [slog.KindInt64], [slog.KindUint64] and [slog.KindFloat64],
+// are compared exactly, regardless of their kinds, e.g. int64(250) is greater than 249.5.
+// Values of kind [slog.KindDuration] and [slog.KindTime] are only compared to values of the same kind.
+// The filter returns false for values that cannot be compared to the given value.
+func IfAttrGreaterThan(key string, value any) slogic.Filter {
+	v := slog.AnyValue(value)
+	return describe("IfAttrGreaterThan", ifAttr(key, func(attr slog.Attr) bool {
+		c, ok := compareValues(attr.Value, v)
+		return ok && c > 0
+	}), key, value)
+}
+
+// IfAttrLessThan returns a [slogic.Filter] that returns true if
+// the record's [slog.Attr] with the given key ([Attribute Paths]) is less than the given value.
+// Values are compared as per [IfAttrGreaterThan].
+func IfAttrLessThan(key string, value any) slogic.Filter {
+	v := slog.AnyValue(value)
+	return describe("IfAttrLessThan", ifAttr(key, func(attr slog.Attr) bool {
+		c, ok := compareValues(attr.Value, v)
+		return ok && c < 0
+	}), key, value)
+}
+
+// IfAttrBetween returns a [slogic.Filter] that returns true if
+// the record's [slog.Attr] with the given key ([Attribute Paths]) is between the given min and max values,
+// inclusive. Values are compared as per [IfAttrGreaterThan],
+// so e.g. math.Inf(1) can be used as the max value to leave it unbounded.
+func IfAttrBetween(key string, min, max any) slogic.Filter {
+	lower, upper := slog.AnyValue(min), slog.AnyValue(max)
+	return describe("IfAttrBetween", ifAttr(key, func(attr slog.Attr) bool {
+		cmin, ok := compareValues(attr.Value, lower)
+		if !ok || cmin < 0 {
+			return false
+		}
+		cmax, ok := compareValues(attr.Value, upper)
+		return ok && cmax <= 0
+	}), key, min, max)
+}
+
+// compareValues returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b,
+// and false if they cannot be compared.
+func compareValues(a, b slog.Value) (int, bool) {
+	switch ka, kb := a.Kind(), b.Kind(); {
+	case ka == slog.KindDuration && kb == slog.KindDuration:
+		return cmp.Compare(a.Duration(), b.Duration()), true
+	case ka == slog.KindTime && kb == slog.KindTime:
+		return a.Time().Compare(b.Time()), true
+	case isNumeric(ka) && isNumeric(kb):
+		return compareNumbers(a, b)
+	default:
+		return 0, false
+	}
+}
+
+func isNumeric(k slog.Kind) bool {
+	return k == slog.KindInt64 || k == slog.KindUint64 || k == slog.KindFloat64
+}
+
+// compareNumbers compares the given numeric values without loss of precision.
+func compareNumbers(a, b slog.Value) (int, bool) {
+	switch a.Kind() {
+	case slog.KindInt64:
+		switch b.Kind() {
+		case slog.KindInt64:
+			return cmp.Compare(a.Int64(), b.Int64()), true
+		case slog.KindUint64:
+			return compareIntUint(a.Int64(), b.Uint64()), true
+		}
+	case slog.KindUint64:
+		switch b.Kind() {
+		case slog.KindInt64:
+			return -compareIntUint(b.Int64(), a.Uint64()), true
+		case slog.KindUint64:
+			return cmp.Compare(a.Uint64(), b.Uint64()), true
+		}
+	case slog.KindFloat64:
+		if b.Kind() != slog.KindFloat64 {
+			c, ok := compareNumbers(b, a)
+			return -c, ok
+		}
+		x, y := a.Float64(), b.Float64()
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return 0, false
+		}
+		return cmp.Compare(x, y), true
+	}
+
+	// b is a float, and a an integer...
+	y := b.Float64()
+	if math.IsNaN(y) {
+		return 0, false
+	}
+	var x big.Float
+	if a.Kind() == slog.KindInt64 {
+		x.SetInt64(a.Int64())
+	} else {
+		x.SetUint64(a.Uint64())
+	}
+	return x.Cmp(big.NewFloat(y)), true
+}
+
+// compareIntUint compares the given signed and unsigned integers.
+func compareIntUint(i int64, u uint64) int {
+	if i < 0 {
+		return -1
+	}
+	return cmp.Compare(uint64(i), u)
+}
+
+// ifAttr returns a [slogic.Filter] that returns true if any [slog.Attr] at the given path,
+// whether added via [slog.Logger.With] or to the record itself, satisfies the predicate.
 func ifAttr(key string, predicate func(attr slog.Attr) bool) slogic.Filter {
-	return func(_ context.Context, r slog.Record) bool {
-		found := false
-		r.Attrs(func(attr slog.Attr) bool {
-			if attr.Key == key && predicate(attr) {
-				found = true
-				return false
+	path := splitPath(key)
+	return func(ctx context.Context, r slog.Record) bool {
+		return anyAttr(ctx, r, path, predicate)
+	}
+}
+
+// anyAttr reports whether any [slog.Attr] at the given path satisfies the predicate.
+func anyAttr(ctx context.Context, r slog.Record, path []string, predicate func(attr slog.Attr) bool) bool {
+	for groups, attr := range slogic.Attrs(ctx, r) {
+		if len(groups) >= len(path) || !slices.Equal(groups, path[:len(groups)]) {
+			continue
+		}
+		if matchAttr(path[len(groups):], attr, predicate) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAttr reports whether the given attribute, or any attribute nested within it,
+// is at the given path relative to it and satisfies the predicate.
+func matchAttr(path []string, attr slog.Attr, predicate func(attr slog.Attr) bool) bool {
+	attr.Value = attr.Value.Resolve()
+	if attr.Key == "" && attr.Value.Kind() == slog.KindGroup {
+		// Groups with empty keys are inlined into their parent...
+		for _, attr := range attr.Value.Group() {
+			if matchAttr(path, attr, predicate) {
+				return true
 			}
+		}
+		return false
+	}
+	if attr.Key != path[0] {
+		return false
+	}
+	if len(path) == 1 {
+		return predicate(attr)
+	}
+	if attr.Value.Kind() != slog.KindGroup {
+		return false
+	}
+	for _, attr := range attr.Value.Group() {
+		if matchAttr(path[1:], attr, predicate) {
 			return true
-		})
-		return found
+		}
+	}
+	return false
+}
+
+// splitPath splits the given key into its dot-separated segments.
+// A dot or backslash preceded by a backslash is treated literally.
+func splitPath(key string) []string {
+	var (
+		path    []string
+		segment strings.Builder
+	)
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case c == '\\' && i+1 < len(key) && (key[i+1] == '.' || key[i+1] == '\\'):
+			i++
+			segment.WriteByte(key[i])
+		case c == '.':
+			path = append(path, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(c)
+		}
 	}
+	return append(path, segment.String())
 }
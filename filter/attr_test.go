@@ -2,7 +2,11 @@ package filter
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"slices"
 	"testing"
 	"time"
 
@@ -144,6 +148,312 @@ func TestIfAttrExists(t *testing.T) {
 	}
 }
 
+func TestIfAttr(t *testing.T) {
+	type user struct{ ID string }
+	errTimeout := errors.New("timeout")
+
+	tests := []struct {
+		name   string
+		filter slogic.Filter
+		attr   slog.Attr
+		want   bool
+	}{
+		{
+			name:   "int",
+			filter: IfAttr("FOO", func(n int) bool { return n > 100 }),
+			attr:   slog.Int("FOO", 250),
+			want:   true,
+		},
+		{
+			name:   "int8 overflow",
+			filter: IfAttr("FOO", func(int8) bool { return true }),
+			attr:   slog.Int("FOO", 250),
+			want:   false,
+		},
+		{
+			name:   "uint from int",
+			filter: IfAttr("FOO", func(n uint) bool { return n == 250 }),
+			attr:   slog.Int("FOO", 250),
+			want:   true,
+		},
+		{
+			name:   "uint from negative int",
+			filter: IfAttr("FOO", func(uint) bool { return true }),
+			attr:   slog.Int("FOO", -1),
+			want:   false,
+		},
+		{
+			name:   "float32",
+			filter: IfAttr("FOO", func(f float32) bool { return f == 0.5 }),
+			attr:   slog.Float64("FOO", 0.5),
+			want:   true,
+		},
+		{
+			name:   "string",
+			filter: IfAttr("FOO", func(s string) bool { return s == "BAR" }),
+			attr:   slog.String("FOO", "BAR"),
+			want:   true,
+		},
+		{
+			name:   "duration",
+			filter: IfAttr("FOO", func(d time.Duration) bool { return d > time.Second }),
+			attr:   slog.Duration("FOO", time.Minute),
+			want:   true,
+		},
+		{
+			name:   "duration from int",
+			filter: IfAttr("FOO", func(time.Duration) bool { return true }),
+			attr:   slog.Int("FOO", 250),
+			want:   false,
+		},
+		{
+			name:   "error",
+			filter: IfAttr("FOO", func(err error) bool { return errors.Is(err, errTimeout) }),
+			attr:   slog.Any("FOO", fmt.Errorf("query: %w", errTimeout)),
+			want:   true,
+		},
+		{
+			name:   "struct",
+			filter: IfAttr("FOO", func(u user) bool { return u.ID == "user_123" }),
+			attr:   slog.Any("FOO", user{ID: "user_123"}),
+			want:   true,
+		},
+		{
+			name:   "log valuer",
+			filter: IfAttr("FOO", func(s string) bool { return s == "BAR" }),
+			attr:   slog.Any("FOO", testLogValuer("BAR")),
+			want:   true,
+		},
+		{
+			name:   "value",
+			filter: IfAttr("FOO", func(v slog.Value) bool { return v.Kind() == slog.KindString }),
+			attr:   slog.Any("FOO", testLogValuer("BAR")),
+			want:   true,
+		},
+		{
+			name:   "wrong type",
+			filter: IfAttr("FOO", func(string) bool { return true }),
+			attr:   slog.Int("FOO", 250),
+			want:   false,
+		},
+		{
+			name:   "missing",
+			filter: IfAttr("FOO", func(string) bool { return true }),
+			attr:   slog.String("BAR", "BAZ"),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testAttr(tt.filter, []slog.Attr{tt.attr})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfAttrGreaterThan(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		value any
+		attr  slog.Value
+		want  bool
+	}{
+		{name: "int", value: 250, attr: slog.Int64Value(251), want: true},
+		{name: "int equal", value: 250, attr: slog.Int64Value(250), want: false},
+		{name: "int float", value: 249.5, attr: slog.Int64Value(250), want: true},
+		{name: "float int", value: 250, attr: slog.Float64Value(249.5), want: false},
+		{name: "negative int uint", value: uint64(0), attr: slog.Int64Value(-1), want: false},
+		{name: "uint int", value: -1, attr: slog.Uint64Value(0), want: true},
+		{name: "large uint int", value: int64(math.MaxInt64), attr: slog.Uint64Value(math.MaxUint64), want: true},
+		{name: "large int float", value: float64(1 << 53), attr: slog.Int64Value(1<<53 + 1), want: true},
+		{name: "float inf", value: math.Inf(-1), attr: slog.Float64Value(-math.MaxFloat64), want: true},
+		{name: "float nan", value: math.NaN(), attr: slog.Float64Value(1), want: false},
+		{name: "duration", value: time.Second, attr: slog.DurationValue(time.Minute), want: true},
+		{name: "duration int", value: 0, attr: slog.DurationValue(time.Minute), want: false},
+		{name: "time", value: now, attr: slog.TimeValue(now.Add(time.Second)), want: true},
+		{name: "string", value: "a", attr: slog.StringValue("b"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testAttr(IfAttrGreaterThan("FOO", tt.value), []slog.Attr{{Key: "FOO", Value: tt.attr}})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfAttrLessThan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		attr  slog.Value
+		want  bool
+	}{
+		{name: "int", value: 250, attr: slog.Int64Value(249), want: true},
+		{name: "int equal", value: 250, attr: slog.Int64Value(250), want: false},
+		{name: "uint float", value: 0.5, attr: slog.Uint64Value(0), want: true},
+		{name: "float uint", value: uint64(1), attr: slog.Float64Value(1.5), want: false},
+		{name: "duration", value: time.Second, attr: slog.DurationValue(time.Millisecond), want: true},
+		{name: "missing", value: 250, attr: slog.Value{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testAttr(IfAttrLessThan("FOO", tt.value), []slog.Attr{{Key: "FOO", Value: tt.attr}})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfAttrBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max any
+		attr     slog.Value
+		want     bool
+	}{
+		{name: "within", min: 100, max: 200, attr: slog.Float64Value(150.5), want: true},
+		{name: "min", min: 100, max: 200, attr: slog.Int64Value(100), want: true},
+		{name: "max", min: 100, max: 200, attr: slog.Uint64Value(200), want: true},
+		{name: "below", min: 100, max: 200, attr: slog.Int64Value(99), want: false},
+		{name: "above", min: 100, max: 200, attr: slog.Int64Value(201), want: false},
+		{name: "unbounded", min: 100, max: math.Inf(1), attr: slog.Uint64Value(math.MaxUint64), want: true},
+		{name: "duration", min: time.Second, max: time.Minute, attr: slog.DurationValue(time.Second), want: true},
+		{name: "mixed", min: time.Second, max: 60, attr: slog.DurationValue(time.Second), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testAttr(IfAttrBetween("FOO", tt.min, tt.max), []slog.Attr{{Key: "FOO", Value: tt.attr}})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfAttrEqualsWithAttrs(t *testing.T) {
+	var handled []string
+	h := slogic.NewHandler(
+		handlerFunc(func(_ context.Context, r slog.Record) error {
+			handled = append(handled, r.Message)
+			return nil
+		}),
+		IfAttrEquals("tenant", "acme"),
+	)
+
+	slog.New(h).With("tenant", "acme").Info("acme")
+	slog.New(h).With("tenant", "other").Info("other")
+	slog.New(h).Info("record", "tenant", "acme")
+
+	if want := []string{"other"}; !slices.Equal(handled, want) {
+		t.Errorf("got: %v, want: %v", handled, want)
+	}
+}
+
+func TestIfAttrEqualsPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		logger func(*slog.Logger) *slog.Logger
+		attrs  []slog.Attr
+		want   bool
+	}{
+		{
+			name:  "group",
+			key:   "http.status",
+			attrs: []slog.Attr{slog.Group("http", slog.Int("status", 500))},
+			want:  true,
+		},
+		{
+			name:  "nested group",
+			key:   "http.response.status",
+			attrs: []slog.Attr{slog.Group("http", slog.Group("response", slog.Int("status", 500)))},
+			want:  true,
+		},
+		{
+			name:  "inlined group",
+			key:   "http.status",
+			attrs: []slog.Attr{slog.Group("http", slog.Group("", slog.Int("status", 500)))},
+			want:  true,
+		},
+		{
+			name:  "ungrouped",
+			key:   "http.status",
+			attrs: []slog.Attr{slog.Int("status", 500)},
+			want:  false,
+		},
+		{
+			name:  "not a group",
+			key:   "http.status",
+			attrs: []slog.Attr{slog.Int("http", 500)},
+			want:  false,
+		},
+		{
+			name:  "escaped",
+			key:   `http\.status`,
+			attrs: []slog.Attr{slog.Int("http.status", 500)},
+			want:  true,
+		},
+		{
+			name:  "unescaped",
+			key:   "http.status",
+			attrs: []slog.Attr{slog.Int("http.status", 500)},
+			want:  false,
+		},
+		{
+			name:   "logger group",
+			key:    "http.status",
+			logger: func(l *slog.Logger) *slog.Logger { return l.WithGroup("http") },
+			attrs:  []slog.Attr{slog.Int("status", 500)},
+			want:   true,
+		},
+		{
+			name:   "logger group and attrs",
+			key:    "req.http.status",
+			logger: func(l *slog.Logger) *slog.Logger { return l.WithGroup("req").With(slog.Group("http", "status", 500)) },
+			want:   true,
+		},
+		{
+			name:   "logger group mismatch",
+			key:    "status",
+			logger: func(l *slog.Logger) *slog.Logger { return l.WithGroup("http") },
+			attrs:  []slog.Attr{slog.Int("status", 500)},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := false
+			h := slogic.NewHandler(
+				handlerFunc(func(context.Context, slog.Record) error { return nil }),
+				func(ctx context.Context, r slog.Record) bool {
+					got = IfAttrEquals(tt.key, 500)(ctx, r)
+					return got
+				},
+			)
+			logger := slog.New(h)
+			if tt.logger != nil {
+				logger = tt.logger(logger)
+			}
+			logger.LogAttrs(context.Background(), slog.LevelInfo, "", tt.attrs...)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func testAttr(filter slogic.Filter, attrs []slog.Attr) bool {
 	r := slog.NewRecord(time.Now(), slog.LevelInfo, "", 0)
 	for _, attr := range attrs {
@@ -151,3 +461,15 @@ func testAttr(filter slogic.Filter, attrs []slog.Attr) bool {
 	}
 	return filter(context.Background(), r)
 }
+
+// handlerFunc is a minimal [slog.Handler] that calls itself for each record.
+type handlerFunc func(context.Context, slog.Record) error
+
+func (f handlerFunc) Enabled(context.Context, slog.Level) bool        { return true }
+func (f handlerFunc) Handle(ctx context.Context, r slog.Record) error { return f(ctx, r) }
+func (f handlerFunc) WithAttrs([]slog.Attr) slog.Handler              { return f }
+func (f handlerFunc) WithGroup(string) slog.Handler                   { return f }
+
+type testLogValuer string
+
+func (v testLogValuer) LogValue() slog.Value { return slog.StringValue(string(v)) }
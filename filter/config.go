@@ -0,0 +1,593 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+// A Config is a declarative description of a [slogic.Filter],
+// suitable for loading from, and dumping to, a JSON configuration file.
+//
+// Exactly one of its fields must be set.
+// The And, Or and Not fields combine nested configs via [slogic.And], [slogic.Or] and [slogic.Not],
+// and the remaining fields each correspond to the filter constructor of the same name.
+// For example:
+//
+//	{
+//	  "and": [
+//	    {"ifLevelAtMost": "WARN"},
+//	    {"not": {"ifAttrExists": "latency_ms"}},
+//	    {"ifAttrEquals": {"key": "http.status", "value": 200}}
+//	  ]
+//	}
+type Config struct {
+	And []Config `json:"and,omitzero"`
+	Or  []Config `json:"or,omitzero"`
+	Not *Config  `json:"not,omitempty"`
+
+	IfLevelEquals  *slog.Level `json:"ifLevelEquals,omitempty"`
+	IfLevelAtLeast *slog.Level `json:"ifLevelAtLeast,omitempty"`
+	IfLevelAtMost  *slog.Level `json:"ifLevelAtMost,omitempty"`
+
+	IfMessageEquals   *string `json:"ifMessageEquals,omitempty"`
+	IfMessageContains *string `json:"ifMessageContains,omitempty"`
+	IfMessageMatches  *string `json:"ifMessageMatches,omitempty"`
+
+	IfAttrEquals   *AttrConfig `json:"ifAttrEquals,omitempty"`
+	IfAttrContains *AttrConfig `json:"ifAttrContains,omitempty"`
+	IfAttrMatches  *AttrConfig `json:"ifAttrMatches,omitempty"`
+	IfAttrExists   *string     `json:"ifAttrExists,omitempty"`
+
+	IfAttrGreaterThan *AttrConfig      `json:"ifAttrGreaterThan,omitempty"`
+	IfAttrLessThan    *AttrConfig      `json:"ifAttrLessThan,omitempty"`
+	IfAttrBetween     *AttrRangeConfig `json:"ifAttrBetween,omitempty"`
+	IfAttrIn          *AttrSetConfig   `json:"ifAttrIn,omitempty"`
+
+	IfTimeAfter   *time.Time  `json:"ifTimeAfter,omitempty"`
+	IfTimeBefore  *time.Time  `json:"ifTimeBefore,omitempty"`
+	IfTimeBetween *TimeConfig `json:"ifTimeBetween,omitempty"`
+
+	SampleRate   *float64      `json:"sampleRate,omitempty"`
+	EveryNth     *uint64       `json:"everyNth,omitempty"`
+	SampleByAttr *SampleConfig `json:"sampleByAttr,omitempty"`
+}
+
+// An AttrConfig holds the arguments of an attribute filter within a [Config].
+//
+// For [IfAttrEquals], Value may be any JSON scalar, or an object holding a [time.Duration]
+// as {"duration": "1.5s"}, per [time.ParseDuration], or a [time.Time] as {"time": "2025-01-01T00:00:00Z"},
+// per RFC 3339. Numbers with a fractional part or an exponent, such as 2.0, are compared as floats,
+// and other numbers as integers. For [IfAttrContains] and [IfAttrMatches], Value must be a string,
+// and for [IfAttrGreaterThan] and [IfAttrLessThan], Value must be a number, duration or time.
+type AttrConfig struct {
+	Key   string `json:"key"`
+	Value any    `json:"value,omitempty"`
+}
+
+// An AttrRangeConfig holds the arguments of [IfAttrBetween] within a [Config].
+// Min and Max must be numbers, durations or times, as per [AttrConfig];
+// either may be omitted to leave the range unbounded.
+type AttrRangeConfig struct {
+	Key string `json:"key"`
+	Min any    `json:"min,omitempty"`
+	Max any    `json:"max,omitempty"`
+}
+
+// An AttrSetConfig holds the arguments of [IfAttrIn] within a [Config].
+type AttrSetConfig struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+// A SampleConfig holds the arguments of [SampleByAttr] within a [Config].
+type SampleConfig struct {
+	Key  string  `json:"key"`
+	Rate float64 `json:"rate"`
+}
+
+// A TimeConfig holds the arguments of [IfTimeBetween] within a [Config].
+type TimeConfig struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FromJSON compiles the given JSON-encoded [Config] into a [slogic.Filter].
+func FromJSON(data []byte) (slogic.Filter, error) {
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("filter: unexpected data after config")
+	}
+	return c.Filter()
+}
+
+// ToJSON returns the JSON encoding of the [Config] describing the given filter.
+// See [ConfigOf] for the filters that can be described.
+func ToJSON(filter slogic.Filter) ([]byte, error) {
+	c, err := ConfigOf(filter)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(c)
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c AttrConfig) MarshalJSON() ([]byte, error) {
+	value, err := marshalScalar(c.Value)
+	if err != nil {
+		return nil, fmt.Errorf("value of %q %w", c.Key, err)
+	}
+	return json.Marshal(struct {
+		Key   string `json:"key"`
+		Value any    `json:"value,omitempty"`
+	}{c.Key, value})
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (c *AttrConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	value, err := scalar(raw.Value)
+	if err != nil {
+		return fmt.Errorf("value of %q %w", raw.Key, err)
+	}
+	c.Key, c.Value = raw.Key, value
+	return nil
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (c AttrRangeConfig) MarshalJSON() ([]byte, error) {
+	min, err := marshalScalar(c.Min)
+	if err != nil {
+		return nil, fmt.Errorf("min of %q %w", c.Key, err)
+	}
+	max, err := marshalScalar(c.Max)
+	if err != nil {
+		return nil, fmt.Errorf("max of %q %w", c.Key, err)
+	}
+	return json.Marshal(struct {
+		Key string `json:"key"`
+		Min any    `json:"min,omitempty"`
+		Max any    `json:"max,omitempty"`
+	}{c.Key, min, max})
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (c *AttrRangeConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Key string `json:"key"`
+		Min any    `json:"min"`
+		Max any    `json:"max"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	min, err := scalar(raw.Min)
+	if err != nil {
+		return fmt.Errorf("min of %q %w", raw.Key, err)
+	}
+	max, err := scalar(raw.Max)
+	if err != nil {
+		return fmt.Errorf("max of %q %w", raw.Key, err)
+	}
+	c.Key, c.Min, c.Max = raw.Key, min, max
+	return nil
+}
+
+// scalar converts the given JSON value, decoded with [json.Decoder.UseNumber],
+// into a string, bool, int64, float64, [time.Duration] or [time.Time] value, or nil.
+func scalar(value any) (any, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n, nil
+		}
+		return value.Float64()
+	case nil, string, bool:
+		return value, nil
+	case map[string]any:
+		if s, ok := value["duration"].(string); ok && len(value) == 1 {
+			return time.ParseDuration(s)
+		}
+		if s, ok := value["time"].(string); ok && len(value) == 1 {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	}
+	return nil, errors.New(`must be a string, number, boolean, {"duration": ...} or {"time": ...}`)
+}
+
+// marshalScalar converts the given value, as per [configValue], into its JSON representation per [scalar].
+func marshalScalar(value any) (any, error) {
+	value, err := configValue(value)
+	if err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case float64:
+		// Retains a fractional part, so that the number is decoded as a float...
+		s := strconv.FormatFloat(value, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return json.Number(s), nil
+	case time.Duration:
+		return map[string]string{"duration": value.String()}, nil
+	case time.Time:
+		return map[string]string{"time": value.Format(time.RFC3339Nano)}, nil
+	}
+	return value, nil
+}
+
+// configValue converts the given value of an attribute filter into the type that represents it
+// faithfully within a [Config], as per [scalar], or returns an error if there is no such type.
+func configValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch v := slog.AnyValue(value); v.Kind() {
+	case slog.KindString:
+		if _, ok := value.(string); ok {
+			return value, nil
+		}
+	case slog.KindBool:
+		return v.Bool(), nil
+	case slog.KindInt64:
+		return v.Int64(), nil
+	case slog.KindFloat64:
+		if f := v.Float64(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return f, nil
+		}
+	case slog.KindDuration:
+		return v.Duration(), nil
+	case slog.KindTime:
+		return v.Time(), nil
+	}
+	return nil, fmt.Errorf("cannot be described as %T %v", value, value)
+}
+
+// Filter compiles the config into a [slogic.Filter].
+func (c Config) Filter() (slogic.Filter, error) {
+	var filters []slogic.Filter
+	set := func(filter slogic.Filter) {
+		filters = append(filters, filter)
+	}
+
+	if c.And != nil {
+		children, err := configFilters(c.And)
+		if err != nil {
+			return nil, err
+		}
+		set(slogic.And(children...))
+	}
+	if c.Or != nil {
+		children, err := configFilters(c.Or)
+		if err != nil {
+			return nil, err
+		}
+		set(slogic.Or(children...))
+	}
+	if c.Not != nil {
+		child, err := c.Not.Filter()
+		if err != nil {
+			return nil, err
+		}
+		set(slogic.Not(child))
+	}
+
+	if c.IfLevelEquals != nil {
+		set(IfLevelEquals(*c.IfLevelEquals))
+	}
+	if c.IfLevelAtLeast != nil {
+		set(IfLevelAtLeast(*c.IfLevelAtLeast))
+	}
+	if c.IfLevelAtMost != nil {
+		set(IfLevelAtMost(*c.IfLevelAtMost))
+	}
+
+	if c.IfMessageEquals != nil {
+		set(IfMessageEquals(*c.IfMessageEquals))
+	}
+	if c.IfMessageContains != nil {
+		set(IfMessageContains(*c.IfMessageContains))
+	}
+	if c.IfMessageMatches != nil {
+		if _, err := regexp.Compile(*c.IfMessageMatches); err != nil {
+			return nil, fmt.Errorf("filter: ifMessageMatches: %w", err)
+		}
+		set(IfMessageMatches(*c.IfMessageMatches))
+	}
+
+	if c.IfAttrEquals != nil {
+		set(IfAttrEquals(c.IfAttrEquals.Key, c.IfAttrEquals.Value))
+	}
+	if c.IfAttrContains != nil {
+		substring, ok := c.IfAttrContains.Value.(string)
+		if !ok {
+			return nil, errors.New("filter: ifAttrContains: value must be a string")
+		}
+		set(IfAttrContains(c.IfAttrContains.Key, substring))
+	}
+	if c.IfAttrMatches != nil {
+		pattern, ok := c.IfAttrMatches.Value.(string)
+		if !ok {
+			return nil, errors.New("filter: ifAttrMatches: value must be a string")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("filter: ifAttrMatches: %w", err)
+		}
+		set(IfAttrMatches(c.IfAttrMatches.Key, pattern))
+	}
+	if c.IfAttrExists != nil {
+		set(IfAttrExists(*c.IfAttrExists))
+	}
+
+	if c.IfAttrGreaterThan != nil {
+		n, ok := ordered(c.IfAttrGreaterThan.Value)
+		if !ok {
+			return nil, errors.New("filter: ifAttrGreaterThan: value must be a number, duration or time")
+		}
+		set(IfAttrGreaterThan(c.IfAttrGreaterThan.Key, n))
+	}
+	if c.IfAttrLessThan != nil {
+		n, ok := ordered(c.IfAttrLessThan.Value)
+		if !ok {
+			return nil, errors.New("filter: ifAttrLessThan: value must be a number, duration or time")
+		}
+		set(IfAttrLessThan(c.IfAttrLessThan.Key, n))
+	}
+	if c.IfAttrBetween != nil {
+		var min, max any = math.Inf(-1), math.Inf(1)
+		if c.IfAttrBetween.Min != nil {
+			n, ok := ordered(c.IfAttrBetween.Min)
+			if !ok {
+				return nil, errors.New("filter: ifAttrBetween: min must be a number, duration or time")
+			}
+			min = n
+		}
+		if c.IfAttrBetween.Max != nil {
+			n, ok := ordered(c.IfAttrBetween.Max)
+			if !ok {
+				return nil, errors.New("filter: ifAttrBetween: max must be a number, duration or time")
+			}
+			max = n
+		}
+		set(IfAttrBetween(c.IfAttrBetween.Key, min, max))
+	}
+	if c.IfAttrIn != nil {
+		set(IfAttrIn(c.IfAttrIn.Key, c.IfAttrIn.Values...))
+	}
+
+	if c.IfTimeAfter != nil {
+		set(IfTimeAfter(*c.IfTimeAfter))
+	}
+	if c.IfTimeBefore != nil {
+		set(IfTimeBefore(*c.IfTimeBefore))
+	}
+	if c.IfTimeBetween != nil {
+		set(IfTimeBetween(c.IfTimeBetween.Start, c.IfTimeBetween.End))
+	}
+
+	if c.SampleRate != nil {
+		set(SampleRate(*c.SampleRate))
+	}
+	if c.EveryNth != nil {
+		set(EveryNth(*c.EveryNth))
+	}
+	if c.SampleByAttr != nil {
+		set(SampleByAttr(c.SampleByAttr.Key, c.SampleByAttr.Rate))
+	}
+
+	switch len(filters) {
+	case 0:
+		return nil, errors.New("filter: config has no filter set")
+	case 1:
+		return filters[0], nil
+	default:
+		return nil, fmt.Errorf("filter: config has %d filters set, want exactly 1", len(filters))
+	}
+}
+
+func configFilters(configs []Config) ([]slogic.Filter, error) {
+	filters := make([]slogic.Filter, len(configs))
+	for i, c := range configs {
+		filter, err := c.Filter()
+		if err != nil {
+			return nil, err
+		}
+		filters[i] = filter
+	}
+	return filters, nil
+}
+
+// ConfigOf returns the [Config] describing the given filter.
+//
+// The filter must be composed solely of [slogic.And], [slogic.Or], [slogic.Not]
+// and the filters of this package that have a corresponding [Config] field;
+// otherwise ConfigOf returns an error.
+func ConfigOf(filter slogic.Filter) (Config, error) {
+	desc, ok := slogic.Inspect(filter)
+	if !ok {
+		return Config{}, errors.New("filter: cannot describe custom filter")
+	}
+
+	var c Config
+	switch slogic.Operator(filter) {
+	case "And", "Or":
+		// Non-nil even if empty, so that an empty And or Or filter is encoded...
+		children := make([]Config, len(desc.Filters))
+		for i, filter := range desc.Filters {
+			child, err := ConfigOf(filter)
+			if err != nil {
+				return Config{}, err
+			}
+			children[i] = child
+		}
+		if desc.Name == "And" {
+			c.And = children
+		} else {
+			c.Or = children
+		}
+		return c, nil
+	case "Not":
+		child, err := ConfigOf(desc.Filters[0])
+		if err != nil {
+			return Config{}, err
+		}
+		c.Not = &child
+		return c, nil
+	}
+
+	// The filter may have been described as one of this package's via [slogic.Describe],
+	// so its arguments are checked rather than trusted...
+	args := &descArgs{desc: desc}
+	switch desc.Name {
+	case "IfLevelEquals":
+		c.IfLevelEquals = ptr(arg[slog.Level](args, 0))
+	case "IfLevelAtLeast":
+		c.IfLevelAtLeast = ptr(arg[slog.Level](args, 0))
+	case "IfLevelAtMost":
+		c.IfLevelAtMost = ptr(arg[slog.Level](args, 0))
+
+	case "IfMessageEquals":
+		c.IfMessageEquals = ptr(arg[string](args, 0))
+	case "IfMessageContains":
+		c.IfMessageContains = ptr(arg[string](args, 0))
+	case "IfMessageMatches":
+		c.IfMessageMatches = ptr(arg[string](args, 0))
+
+	case "IfAttrEquals":
+		c.IfAttrEquals = &AttrConfig{Key: arg[string](args, 0), Value: args.value(1, "value")}
+	case "IfAttrContains":
+		c.IfAttrContains = &AttrConfig{Key: arg[string](args, 0), Value: arg[string](args, 1)}
+	case "IfAttrMatches":
+		c.IfAttrMatches = &AttrConfig{Key: arg[string](args, 0), Value: arg[string](args, 1)}
+	case "IfAttrExists":
+		c.IfAttrExists = ptr(arg[string](args, 0))
+
+	case "IfAttrGreaterThan":
+		c.IfAttrGreaterThan = &AttrConfig{Key: arg[string](args, 0), Value: args.value(1, "value")}
+	case "IfAttrLessThan":
+		c.IfAttrLessThan = &AttrConfig{Key: arg[string](args, 0), Value: args.value(1, "value")}
+	case "IfAttrBetween":
+		c.IfAttrBetween = &AttrRangeConfig{Key: arg[string](args, 0), Min: args.limit(1, "min"), Max: args.limit(2, "max")}
+	case "IfAttrIn":
+		c.IfAttrIn = &AttrSetConfig{Key: arg[string](args, 0), Values: arg[setValues](args, 1)}
+
+	case "IfTimeAfter":
+		c.IfTimeAfter = ptr(arg[time.Time](args, 0))
+	case "IfTimeBefore":
+		c.IfTimeBefore = ptr(arg[time.Time](args, 0))
+	case "IfTimeBetween":
+		c.IfTimeBetween = &TimeConfig{Start: arg[time.Time](args, 0), End: arg[time.Time](args, 1)}
+
+	case "SampleRate":
+		c.SampleRate = ptr(arg[float64](args, 0))
+	case "EveryNth":
+		c.EveryNth = ptr(arg[uint64](args, 0))
+	case "SampleByAttr":
+		c.SampleByAttr = &SampleConfig{Key: arg[string](args, 0), Rate: arg[float64](args, 1)}
+
+	default:
+		return Config{}, fmt.Errorf("filter: cannot describe %s filter", desc.Name)
+	}
+	if args.err != nil {
+		return Config{}, args.err
+	}
+	return c, nil
+}
+
+// descArgs reads the arguments of a [slogic.Description] for [ConfigOf],
+// retaining the first error encountered.
+type descArgs struct {
+	desc slogic.Description
+	err  error
+}
+
+// arg returns the i-th argument of the description, which must be of type T.
+func arg[T any](a *descArgs, i int) T {
+	var zero T
+	if i >= len(a.desc.Args) {
+		a.fail(fmt.Errorf("filter: cannot describe %s filter with %d arguments", a.desc.Name, len(a.desc.Args)))
+		return zero
+	}
+	v, ok := a.desc.Args[i].(T)
+	if !ok {
+		a.fail(fmt.Errorf("filter: cannot describe %s filter with %T argument", a.desc.Name, a.desc.Args[i]))
+		return zero
+	}
+	return v
+}
+
+// value returns the i-th argument of the description, an attribute value, as per [configValue].
+func (a *descArgs) value(i int, name string) any {
+	return a.convert(arg[any](a, i), name)
+}
+
+// limit returns the i-th argument of the description, a bound of [IfAttrBetween], as per [bound].
+func (a *descArgs) limit(i int, name string) any {
+	return a.convert(bound(arg[any](a, i)), name)
+}
+
+func (a *descArgs) convert(v any, name string) any {
+	v, err := configValue(v)
+	if err != nil {
+		a.fail(fmt.Errorf("filter: %s: %s %w", a.desc.Name, name, err))
+	}
+	return v
+}
+
+func (a *descArgs) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// ordered reports whether the given value, as decoded from JSON, is a number, duration or time.
+func ordered(v any) (any, bool) {
+	switch v.(type) {
+	case int64, float64, time.Duration, time.Time:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// bound returns the given bound of [IfAttrBetween], or nil if it is infinite, i.e. unbounded.
+func bound(v any) any {
+	if f, ok := v.(float64); ok && math.IsInf(f, 0) {
+		return nil
+	}
+	return v
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
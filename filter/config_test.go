@@ -0,0 +1,328 @@
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+func TestFromJSON(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := func(level slog.Level, msg string, attrs ...slog.Attr) slog.Record {
+		r := slog.NewRecord(now, level, msg, 0)
+		r.AddAttrs(attrs...)
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		json   string
+		record slog.Record
+		want   bool
+	}{
+		{
+			name:   "level",
+			json:   `{"ifLevelAtLeast": "WARN"}`,
+			record: record(slog.LevelError, ""),
+			want:   true,
+		},
+		{
+			name:   "message",
+			json:   `{"ifMessageMatches": "^GET /health"}`,
+			record: record(slog.LevelInfo, "GET /healthz"),
+			want:   true,
+		},
+		{
+			name:   "attr integer",
+			json:   `{"ifAttrEquals": {"key": "http.status", "value": 500}}`,
+			record: record(slog.LevelInfo, "", slog.Group("http", "status", 500)),
+			want:   true,
+		},
+		{
+			name:   "attr float",
+			json:   `{"ifAttrEquals": {"key": "ratio", "value": 0.5}}`,
+			record: record(slog.LevelInfo, "", slog.Float64("ratio", 0.5)),
+			want:   true,
+		},
+		{
+			name:   "attr set",
+			json:   `{"ifAttrIn": {"key": "user_id", "values": ["user_1", "user_2"]}}`,
+			record: record(slog.LevelInfo, "", slog.String("user_id", "user_2")),
+			want:   true,
+		},
+		{
+			name:   "time",
+			json:   `{"ifTimeBetween": {"start": "2025-01-01T00:00:00Z", "end": "2025-01-02T00:00:00Z"}}`,
+			record: record(slog.LevelInfo, ""),
+			want:   true,
+		},
+		{
+			name: "tree",
+			json: `{"and": [
+				{"not": {"or": [
+					{"ifLevelEquals": "ERROR"},
+					{"and": [{"ifLevelEquals": "WARN"}, {"ifAttrExists": "latency_ms"}]}
+				]}},
+				{"ifLevelAtMost": "WARN"}
+			]}`,
+			record: record(slog.LevelWarn, "", slog.Int("latency_ms", 250)),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := FromJSON([]byte(tt.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := filter(context.Background(), tt.record)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromJSONError(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "empty", json: `{}`},
+		{name: "multiple", json: `{"ifLevelEquals": "INFO", "ifMessageEquals": "x"}`},
+		{name: "unknown field", json: `{"ifLevelIs": "INFO"}`},
+		{name: "invalid level", json: `{"ifLevelEquals": "LOUD"}`},
+		{name: "invalid pattern", json: `{"ifMessageMatches": "("}`},
+		{name: "invalid attr value", json: `{"ifAttrContains": {"key": "k", "value": 1}}`},
+		{name: "invalid attr bound", json: `{"ifAttrBetween": {"key": "k", "min": "1"}}`},
+		{name: "nested", json: `{"not": {"and": [{}]}}`},
+		{name: "trailing data", json: `{"ifAttrExists": "a"} garbage`},
+		{name: "trailing config", json: `{"ifAttrExists": "a"} {"ifAttrExists": "b"}`},
+		{name: "invalid duration", json: `{"ifAttrEquals": {"key": "k", "value": {"duration": "soon"}}}`},
+		{name: "sample value", json: `{"sampleByAttr": {"key": "trace_id", "value": 0.05}}`},
+		{name: "invalid attr object", json: `{"ifAttrEquals": {"key": "k", "value": {"seconds": 1}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromJSON([]byte(tt.json)); err == nil {
+				t.Error("got: nil, want: error")
+			}
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := slogic.Or(
+		slogic.And(IfLevelAtMost(slog.LevelInfo), slogic.Not(IfAttrEquals("status", 500))),
+		IfMessageContains("health"),
+		IfTimeBetween(start, start.Add(time.Hour)),
+		slogic.And(SampleRate(0.25), EveryNth(10), SampleByAttr("trace_id", 0.05)),
+	)
+
+	data, err := ToJSON(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"or":[{"and":[{"ifLevelAtMost":"INFO"},{"not":{"ifAttrEquals":{"key":"status","value":500}}}]},` +
+		`{"ifMessageContains":"health"},` +
+		`{"ifTimeBetween":{"start":"2025-01-01T00:00:00Z","end":"2025-01-01T01:00:00Z"}},` +
+		`{"and":[{"sampleRate":0.25},{"everyNth":10},{"sampleByAttr":{"key":"trace_id","rate":0.05}}]}]}`
+	if string(data) != want {
+		t.Errorf("got: %s, want: %s", data, want)
+	}
+
+	// Round-trips through FromJSON...
+	g, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ToJSON(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != want {
+		t.Errorf("got: %s, want: %s", again, want)
+	}
+
+	custom := func(context.Context, slog.Record) bool { return true }
+	if _, err := ToJSON(slogic.And(IfLevelAtMost(slog.LevelInfo), custom)); err == nil {
+		t.Error("got: nil, want: error")
+	}
+}
+
+func TestToJSONAttr(t *testing.T) {
+	f, err := Parse("attr.latency_ms >= 250 && attr.size < 1.5 && attr.retries > 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f = slogic.And(f, IfAttrIn("user_id", "user_1", "user_2"))
+
+	data, err := ToJSON(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"and":[{"and":[{"ifAttrBetween":{"key":"latency_ms","min":250}},` +
+		`{"ifAttrLessThan":{"key":"size","value":1.5}},` +
+		`{"ifAttrGreaterThan":{"key":"retries","value":3}}]},` +
+		`{"ifAttrIn":{"key":"user_id","values":["user_1","user_2"]}}]}`
+	if string(data) != want {
+		t.Errorf("got: %s, want: %s", data, want)
+	}
+
+	// Round-trips through FromJSON...
+	g, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.String(), f.String(); got != want {
+		t.Errorf("got: %s, want: %s", got, want)
+	}
+}
+
+func TestAttrConfigJSON(t *testing.T) {
+	var c AttrConfig
+	if err := json.Unmarshal([]byte(`{"key": "k", "value": 9007199254740993}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Value != int64(9007199254740993) {
+		t.Errorf("got: %#v, want: %#v", c.Value, int64(9007199254740993))
+	}
+}
+
+func TestToJSONRoundTrip(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 500, time.UTC)
+	tests := []struct {
+		name   string
+		filter slogic.Filter
+		json   string
+		attr   slog.Attr
+	}{
+		{
+			name:   "string",
+			filter: IfAttrEquals("x", "2"),
+			json:   `{"ifAttrEquals":{"key":"x","value":"2"}}`,
+			attr:   slog.String("x", "2"),
+		},
+		{
+			name:   "bool",
+			filter: IfAttrEquals("x", true),
+			json:   `{"ifAttrEquals":{"key":"x","value":true}}`,
+			attr:   slog.Bool("x", true),
+		},
+		{
+			name:   "integer",
+			filter: IfAttrEquals("x", 2),
+			json:   `{"ifAttrEquals":{"key":"x","value":2}}`,
+			attr:   slog.Int("x", 2),
+		},
+		{
+			name:   "float",
+			filter: IfAttrEquals("x", 2.0),
+			json:   `{"ifAttrEquals":{"key":"x","value":2.0}}`,
+			attr:   slog.Float64("x", 2),
+		},
+		{
+			name:   "float exponent",
+			filter: IfAttrEquals("x", 1e21),
+			json:   `{"ifAttrEquals":{"key":"x","value":1e+21}}`,
+			attr:   slog.Float64("x", 1e21),
+		},
+		{
+			name:   "duration",
+			filter: IfAttrEquals("d", time.Second),
+			json:   `{"ifAttrEquals":{"key":"d","value":{"duration":"1s"}}}`,
+			attr:   slog.Duration("d", time.Second),
+		},
+		{
+			name:   "time",
+			filter: IfAttrEquals("t", start),
+			json:   `{"ifAttrEquals":{"key":"t","value":{"time":"2025-01-01T12:00:00.0000005Z"}}}`,
+			attr:   slog.Time("t", start),
+		},
+		{
+			name:   "duration greater than",
+			filter: IfAttrGreaterThan("d", time.Millisecond),
+			json:   `{"ifAttrGreaterThan":{"key":"d","value":{"duration":"1ms"}}}`,
+			attr:   slog.Duration("d", time.Second),
+		},
+		{
+			name:   "float less than",
+			filter: IfAttrLessThan("x", 3.0),
+			json:   `{"ifAttrLessThan":{"key":"x","value":3.0}}`,
+			attr:   slog.Float64("x", 2.5),
+		},
+		{
+			name:   "time between",
+			filter: IfAttrBetween("t", start, math.Inf(1)),
+			json:   `{"ifAttrBetween":{"key":"t","min":{"time":"2025-01-01T12:00:00.0000005Z"}}}`,
+			attr:   slog.Time("t", start.Add(time.Hour)),
+		},
+		{
+			name:   "empty and",
+			filter: slogic.And(),
+			json:   `{"and":[]}`,
+		},
+		{
+			name:   "empty or",
+			filter: slogic.Not(slogic.Or()),
+			json:   `{"not":{"or":[]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ToJSON(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("got: %s, want: %s", data, tt.json)
+			}
+
+			g, err := FromJSON(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := slog.NewRecord(start, slog.LevelInfo, "", 0)
+			r.AddAttrs(tt.attr)
+			if got, want := g(context.Background(), r), tt.filter(context.Background(), r); got != want {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
+
+func TestConfigOfError(t *testing.T) {
+	type status string
+	custom := func(context.Context, slog.Record) bool { return true }
+	tests := []struct {
+		name   string
+		filter slogic.Filter
+	}{
+		{name: "described without args", filter: slogic.Describe(custom, slogic.Description{Name: "IfLevelEquals"})},
+		{name: "described with wrong args", filter: slogic.Describe(custom, slogic.Description{Name: "IfAttrEquals", Args: []any{1, 2}})},
+		{name: "described as operator", filter: slogic.Describe(custom, slogic.Description{Name: "Not"})},
+		{name: "seeded sample rate", filter: SampleRateWithSource(0.5, rand.NewPCG(1, 2))},
+		{name: "unsigned", filter: IfAttrEquals("x", uint64(2))},
+		{name: "named string", filter: IfAttrEquals("x", status("ok"))},
+		{name: "NaN", filter: IfAttrLessThan("x", math.NaN())},
+		{name: "struct", filter: IfAttrEquals("x", struct{}{})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConfigOf(tt.filter); err == nil {
+				t.Error("got: nil, want: error")
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+// IfContextValue returns a [slogic.Filter] that returns true if
+// the context carries a value for the given key for which the given predicate returns true.
+//
+// The predicate is passed the value as returned by [context.Context.Value],
+// so typically asserts its type:
+//
+//	filter.IfContextValue(tenantKey{}, func(v any) bool {
+//		tenant, ok := v.(string)
+//		return ok && tenant == "acme"
+//	})
+func IfContextValue(key any, predicate func(any) bool) slogic.Filter {
+	return describe("IfContextValue", func(ctx context.Context, _ slog.Record) bool {
+		v := ctx.Value(key)
+		return v != nil && predicate(v)
+	}, key)
+}
+
+// IfContextDone returns a [slogic.Filter] that returns true if
+// the context is done, e.g. as the request it belongs to was canceled.
+func IfContextDone() slogic.Filter {
+	return describe("IfContextDone", func(ctx context.Context, _ slog.Record) bool {
+		return ctx.Err() != nil
+	})
+}
+
+// IfContextDeadlineWithin returns a [slogic.Filter] that returns true if
+// the context has a deadline that is within the given duration from now, or has passed.
+// Contexts without a deadline are never within it.
+func IfContextDeadlineWithin(d time.Duration) slogic.Filter {
+	return describe("IfContextDeadlineWithin", func(ctx context.Context, _ slog.Record) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= d
+	}, d)
+}
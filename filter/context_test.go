@@ -0,0 +1,132 @@
+package filter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type contextKey struct{}
+
+func TestIfContextValue(t *testing.T) {
+	isAcme := func(v any) bool {
+		tenant, ok := v.(string)
+		return ok && tenant == "acme"
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{
+			name: "match",
+			ctx:  context.WithValue(context.Background(), contextKey{}, "acme"),
+			want: true,
+		},
+		{
+			name: "no match",
+			ctx:  context.WithValue(context.Background(), contextKey{}, "globex"),
+			want: false,
+		},
+		{
+			name: "wrong type",
+			ctx:  context.WithValue(context.Background(), contextKey{}, 42),
+			want: false,
+		},
+		{
+			name: "missing",
+			ctx:  context.Background(),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IfContextValue(contextKey{}, isAcme)(tt.ctx, slog.Record{})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfContextDone(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{
+			name: "canceled",
+			ctx:  canceled,
+			want: true,
+		},
+		{
+			name: "expired",
+			ctx:  expired,
+			want: true,
+		},
+		{
+			name: "active",
+			ctx:  context.Background(),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IfContextDone()(tt.ctx, slog.Record{})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfContextDeadlineWithin(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadline time.Duration
+		want     bool
+	}{
+		{
+			name:     "within",
+			deadline: time.Second,
+			want:     true,
+		},
+		{
+			name:     "passed",
+			deadline: -time.Second,
+			want:     true,
+		},
+		{
+			name:     "beyond",
+			deadline: time.Hour,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+			got := IfContextDeadlineWithin(time.Minute)(ctx, slog.Record{})
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no deadline", func(t *testing.T) {
+		if got := IfContextDeadlineWithin(time.Minute)(context.Background(), slog.Record{}); got {
+			t.Errorf("got: %v, want: %v", got, false)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package filter
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.luke.ph/slogic"
+)
+
+// levelOff is the minimum level of the "off" directive, which no record reaches.
+const levelOff = slog.Level(math.MaxInt)
+
+// ParseDirectives compiles the given comma-separated list of directives, in the style of
+// Rust's RUST_LOG environment variable, into a [slogic.Filter] that returns true for records
+// below the minimum level set for the package they were logged from. For example:
+//
+//	info,github.com/acme/db=debug,github.com/acme/cache=warn
+//
+// Each directive is either a level, setting the minimum level of every package,
+// or an import path and level separated by "=", setting the minimum level of the package
+// with that import path, and of any package within it. Levels are as per [slog.Level.UnmarshalText],
+// such as "debug" or "WARN+2", or "off" to filter out every record.
+//
+// A record's package is identified by its PC, as per [IfSourcePackage],
+// and is subject to the directive with the longest matching import path, or else to the level directive.
+// If there is no level directive, records from other packages, or without a PC, are not filtered out.
+// Later directives override earlier ones for the same package.
+func ParseDirectives(directives string) (slogic.Filter, error) {
+	f, err := parseDirectives(directives)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+	return f, nil
+}
+
+// DirectivesFromEnv compiles the directives held by the environment variable with the given name,
+// as per [ParseDirectives], or the given fallback directives if the variable is unset or empty.
+func DirectivesFromEnv(name, fallback string) (slogic.Filter, error) {
+	directives := os.Getenv(name)
+	if directives == "" {
+		directives = fallback
+	}
+	f, err := parseDirectives(directives)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %s: %w", name, err)
+	}
+	return f, nil
+}
+
+func parseDirectives(directives string) (slogic.Filter, error) {
+	type directive struct {
+		path  string
+		level slog.Level
+	}
+	var (
+		ds         []directive
+		def        slog.Level
+		hasDefault bool
+	)
+	for d := range strings.SplitSeq(directives, ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		path, text, ok := strings.Cut(d, "=")
+		if !ok {
+			path, text = "", d
+		}
+		path = strings.TrimSuffix(strings.TrimSpace(path), "/")
+		if ok && path == "" {
+			return nil, fmt.Errorf("invalid directive %q: missing import path", d)
+		}
+
+		level := levelOff
+		if text = strings.TrimSpace(text); !strings.EqualFold(text, "off") {
+			if err := level.UnmarshalText([]byte(text)); err != nil {
+				return nil, fmt.Errorf("invalid directive %q: %w", d, err)
+			}
+		}
+
+		if path == "" {
+			def, hasDefault = level, true
+			continue
+		}
+		ds = slices.DeleteFunc(ds, func(d directive) bool { return d.path == path })
+		ds = append(ds, directive{path: path, level: level})
+	}
+
+	// Matches the longest import paths first...
+	slices.SortStableFunc(ds, func(a, b directive) int {
+		return cmp.Compare(len(b.path), len(a.path))
+	})
+	minimum := func(pkg string) (slog.Level, bool) {
+		for _, d := range ds {
+			if pkg == d.path || strings.HasPrefix(pkg, d.path+"/") {
+				return d.level, true
+			}
+		}
+		return def, hasDefault
+	}
+
+	// Records below every directive's level are filtered out regardless of their package,
+	// so a [slogic.Handler] can report their levels as disabled...
+	lowest := slog.Level(math.MinInt)
+	if hasDefault {
+		lowest = def
+		for _, d := range ds {
+			lowest = min(lowest, d.level)
+		}
+	}
+
+	var levels sync.Map // of uintptr to the minimum level of the PC's package
+	levelOf := func(r slog.Record) slog.Level {
+		if v, ok := levels.Load(r.PC); ok {
+			return v.(slog.Level)
+		}
+		level, known := def, hasDefault
+		if r.PC != 0 {
+			level, known = minimum(functionPackage(frameOf(r.PC).Function))
+		}
+		if !known {
+			level = math.MinInt
+		}
+		v, _ := levels.LoadOrStore(r.PC, level)
+		return v.(slog.Level)
+	}
+	return slogic.Describe(func(_ context.Context, r slog.Record) bool {
+		return r.Level < levelOf(r)
+	}, slogic.Description{
+		Name:         "ParseDirectives",
+		Args:         []any{directives},
+		MinimumLevel: levelOf,
+		LowestLevel:  lowest,
+	}), nil
+}
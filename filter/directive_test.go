@@ -0,0 +1,160 @@
+package filter
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+func TestParseDirectives(t *testing.T) {
+	// PCs within functions of other packages, as if they had logged...
+	slogPC := reflect.ValueOf(slog.String).Pointer() + 1
+	stringsPC := reflect.ValueOf(strings.ToUpper).Pointer() + 1
+
+	tests := []struct {
+		name       string
+		directives string
+		pc         uintptr
+		level      slog.Level
+		want       bool
+	}{
+		{name: "default", directives: "info", pc: callerPC(), level: slog.LevelDebug, want: true},
+		{name: "default kept", directives: "info", pc: callerPC(), level: slog.LevelInfo, want: false},
+		{name: "package", directives: "info,go.luke.ph/slogic/filter=debug", pc: callerPC(), level: slog.LevelDebug, want: false},
+		{name: "parent package", directives: "info,go.luke.ph/slogic=debug", pc: callerPC(), level: slog.LevelDebug, want: false},
+		{name: "longest prefix", directives: "go.luke.ph/slogic/filter=error,go.luke.ph=debug", pc: callerPC(), level: slog.LevelWarn, want: true},
+		{name: "partial element", directives: "warn,go.luke.ph/slog=debug", pc: callerPC(), level: slog.LevelInfo, want: true},
+		{name: "other package", directives: "debug,log=warn", pc: slogPC, level: slog.LevelInfo, want: true},
+		{name: "unmatched package", directives: "log=warn", pc: stringsPC, level: slog.LevelDebug - 10, want: false},
+		{name: "later overrides", directives: "strings=debug,strings=error", pc: stringsPC, level: slog.LevelWarn, want: true},
+		{name: "off", directives: "debug,strings=off", pc: stringsPC, level: slog.LevelError + 100, want: true},
+		{name: "offset", directives: "warn+2", pc: stringsPC, level: slog.LevelWarn, want: true},
+		{name: "no PC", directives: "warn,go.luke.ph=debug", pc: 0, level: slog.LevelInfo, want: true},
+		{name: "whitespace", directives: " warn , strings = Debug ,", pc: stringsPC, level: slog.LevelDebug, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseDirectives(tt.directives)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Evaluates twice, the second time from the cache...
+			for range 2 {
+				r := slog.NewRecord(time.Now(), tt.level, "", tt.pc)
+				if got := f(context.Background(), r); got != tt.want {
+					t.Errorf("got: %v, want: %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDirectivesError(t *testing.T) {
+	for _, directives := range []string{"loud", "=debug", "strings=", "strings=debug=info"} {
+		t.Run(directives, func(t *testing.T) {
+			if _, err := ParseDirectives(directives); err == nil {
+				t.Error("got: nil, want: error")
+			}
+		})
+	}
+}
+
+func TestParseDirectivesEnabled(t *testing.T) {
+	tests := []struct {
+		directives string
+		want       []slog.Level
+	}{
+		{directives: "warn,strings=info", want: []slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError}},
+		{directives: "off,strings=error", want: []slog.Level{slog.LevelError}},
+		{directives: "strings=error", want: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.directives, func(t *testing.T) {
+			f, err := ParseDirectives(tt.directives)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := slogic.NewHandler(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}), f)
+
+			var got []slog.Level
+			for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+				if h.Enabled(context.Background(), level) {
+					got = append(got, level)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDirectivesMinimumLevel(t *testing.T) {
+	f, err := ParseDirectives("off,go.luke.ph/slogic/filter=warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.String(), "ParseDirectives(off,go.luke.ph/slogic/filter=warn)"; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+
+	var buf strings.Builder
+	h := slogic.NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}), f)
+	logger := slog.New(h)
+	ctx := slogic.WithMinimumLevel(context.Background(), slog.LevelDebug)
+
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("got: %v, want: %v", true, false)
+	}
+	if !h.Enabled(ctx, slog.LevelDebug) {
+		t.Errorf("got: %v, want: %v", false, true)
+	}
+	logger.Info("a")
+	logger.DebugContext(ctx, "b")
+	logger.Warn("c")
+
+	if got, want := buf.String(), "level=DEBUG msg=b\nlevel=WARN msg=c\n"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestDirectivesFromEnv(t *testing.T) {
+	t.Setenv("TEST_LOG", "error")
+	f, err := DirectivesFromEnv("TEST_LOG", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f(context.Background(), slog.NewRecord(time.Now(), slog.LevelWarn, "", 0)); !got {
+		t.Errorf("got: %v, want: %v", got, true)
+	}
+
+	f, err = DirectivesFromEnv("TEST_LOG_UNSET", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f(context.Background(), slog.NewRecord(time.Now(), slog.LevelWarn, "", 0)); got {
+		t.Errorf("got: %v, want: %v", got, false)
+	}
+
+	t.Setenv("TEST_LOG", "loud")
+	if _, err := DirectivesFromEnv("TEST_LOG", "debug"); err == nil || !strings.Contains(err.Error(), "TEST_LOG") {
+		t.Errorf("got: %v, want: error mentioning TEST_LOG", err)
+	}
+}
@@ -1,6 +1,8 @@
 package filter_test
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"os"
 
@@ -83,3 +85,72 @@ func ExampleIfAttrExists() {
 	// time=1970-01-01T00:00:00.000Z level=INFO msg="Authenticated user" user_id=user_123 roles=admin,reader
 	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
 }
+
+func ExampleIfAttrEquals_path() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.IfAttrEquals("http.status", 200),
+	)
+
+	logger := slog.New(handler)
+
+	logger.Info("Handled request", slog.Group("http", "method", "GET", "status", 200)) // Filtered
+	logger.Info("Handled request", slog.Group("http", "method", "POST", "status", 500))
+	logger.WithGroup("http").Info("Handled request", "method", "GET", "status", 200) // Filtered
+	logger.Info("Handled request", "status", 200)
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Handled request" http.method=POST http.status=500
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Handled request" status=200
+}
+
+func ExampleIfAttrLessThan() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.IfAttrLessThan("latency_ms", 100),
+	)
+
+	logger := slog.New(handler)
+
+	logger.Warn("Executed database query", "query", "getUserProfile", "latency_ms", 25) // Filtered
+	logger.Warn("Executed slow database query", "query", "getUserProfile", "latency_ms", 250)
+	logger.Warn("Executed slow database query", "query", "listOrders", "latency_ms", 99.5) // Filtered
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Executed slow database query" query=getUserProfile latency_ms=250
+}
+
+func ExampleIfAttr() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		filter.IfAttr("error", func(err error) bool {
+			return errors.Is(err, context.Canceled)
+		}),
+	)
+
+	logger := slog.New(handler)
+
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", errors.New("gateway_timeout"))
+	logger.Error("Failed to process payment", "order_id", "ORD-9877", "error", context.Canceled) // Filtered
+	logger.Error("Failed to process payment", "order_id", "ORD-9878", "error", "canceled")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9878 error=canceled
+}
+
+func ExampleIfAttrIn() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		// E.g. synthetic monitors, or a set loaded via filter.WatchSet...
+		filter.IfAttrIn("user_id", "monitor_1", "monitor_2", "monitor_3"),
+	)
+
+	logger := slog.New(handler)
+
+	logger.Info("Authenticated user", "user_id", "monitor_2", "roles", "reader") // Filtered
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Authenticated user" user_id=user_123 roles=admin,reader
+}
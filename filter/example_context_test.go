@@ -0,0 +1,33 @@
+package filter_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func ExampleIfContextDone() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		slogic.And(
+			filter.IfLevelAtMost(slog.LevelInfo),
+			filter.IfContextDone(),
+		),
+	)
+
+	logger := slog.New(handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger.InfoContext(ctx, "Received request", "method", "GET", "path", "/api/users", "ip", "192.168.1.1")
+	// E.g. the client disconnects...
+	cancel()
+	logger.InfoContext(ctx, "Executed database query", "query", "getUserProfile", "latency_ms", 25) // Filtered
+	logger.ErrorContext(ctx, "Failed to write response", "error", "context canceled")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Received request" method=GET path=/api/users ip=192.168.1.1
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to write response" error="context canceled"
+}
@@ -0,0 +1,30 @@
+package filter_test
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func ExampleParseDirectives() {
+	// E.g. via filter.DirectivesFromEnv("LOG_LEVEL", "info")...
+	f, err := filter.ParseDirectives("debug,go.luke.ph/slogic/filter_test=warn")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	handler := slogic.NewHandler(slog.NewTextHandler(os.Stdout, opts), f)
+
+	logger := slog.New(handler)
+
+	logger.Debug("Received request", "method", "GET", "path", "/api/users", "ip", "192.168.1.1") // Filtered
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader")            // Filtered
+	logger.Warn("Executed slow database query", "query", "getUserProfile", "latency_ms", 250)
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Executed slow database query" query=getUserProfile latency_ms=250
+}
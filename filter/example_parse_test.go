@@ -0,0 +1,41 @@
+package filter_test
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func ExampleParse() {
+	f, err := filter.Parse(`level <= INFO || level == WARN && !attr.latency_ms`)
+	if err != nil {
+		panic(err)
+	}
+
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		f,
+	)
+
+	logger := slog.New(handler)
+
+	logger.Debug("Received request", "method", "GET", "path", "/api/users", "ip", "192.168.1.1") // Filtered
+	logger.Info("Authenticated user", "user_id", "user_123", "roles", "admin,reader")            // Filtered
+	logger.Warn("Executed slow database query", "query", "getUserProfile", "latency_ms", 250)
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Executed slow database query" query=getUserProfile latency_ms=250
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
+}
+
+func ExampleParse_error() {
+	_, err := filter.Parse(`level >= WARN && msg ~ /(health/`)
+	fmt.Println(err)
+
+	// Output:
+	// filter: syntax error at column 24: invalid regular expression: error parsing regexp: missing closing ): `(health`
+}
@@ -0,0 +1,29 @@
+package filter_test
+
+import (
+	"log/slog"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func ExampleRateLimit() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		// Allows bursts of 2 records per message, refilling at 1 per minute...
+		filter.RateLimit(1.0/60, 2, filter.ByMessage),
+	)
+
+	logger := slog.New(handler)
+
+	for i := range 3 {
+		logger.Warn("Cache miss", "attempt", i) // Filtered unless attempt is 0 or 1
+	}
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Cache miss" attempt=0
+	// time=1970-01-01T00:00:00.000Z level=WARN msg="Cache miss" attempt=1
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
+}
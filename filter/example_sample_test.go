@@ -0,0 +1,52 @@
+package filter_test
+
+import (
+	"log/slog"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func ExampleEveryNth() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		slogic.And(
+			filter.IfMessageEquals("Polled queue"),
+			filter.EveryNth(3),
+		),
+	)
+
+	logger := slog.New(handler)
+
+	for i := range 5 {
+		logger.Info("Polled queue", "attempt", i) // Filtered unless attempt is 0 or 3
+	}
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Polled queue" attempt=0
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Polled queue" attempt=3
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
+}
+
+func ExampleSampleByAttr() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		slogic.And(
+			filter.IfLevelAtMost(slog.LevelInfo),
+			// Keeps the same half of traces in every service...
+			filter.SampleByAttr("trace_id", 0.5),
+		),
+	)
+
+	logger := slog.New(handler)
+
+	for _, traceID := range []string{"4bf92f3577b34da6", "00f067aa0ba902b7", "a3ce929d0e0e4736"} {
+		logger.Info("Received request", "trace_id", traceID)
+	}
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Received request" trace_id=00f067aa0ba902b7
+	// time=1970-01-01T00:00:00.000Z level=INFO msg="Received request" trace_id=a3ce929d0e0e4736
+}
@@ -0,0 +1,29 @@
+package filter_test
+
+import (
+	"log/slog"
+	"os"
+
+	"go.luke.ph/slogic"
+	"go.luke.ph/slogic/filter"
+)
+
+func ExampleIfSourceFunction() {
+	handler := slogic.NewHandler(
+		slog.NewTextHandler(os.Stdout, opts),
+		// E.g. a noisy dependency, via filter.IfSourcePackage("github.com/acme/queue/...")...
+		filter.IfSourceFunction(`\.pollQueue$`),
+	)
+
+	logger := slog.New(handler)
+
+	pollQueue(logger) // Filtered
+	logger.Error("Failed to process payment", "order_id", "ORD-9876", "error", "gateway_timeout")
+
+	// Output:
+	// time=1970-01-01T00:00:00.000Z level=ERROR msg="Failed to process payment" order_id=ORD-9876 error=gateway_timeout
+}
+
+func pollQueue(logger *slog.Logger) {
+	logger.Info("Polled queue", "messages", 0)
+}
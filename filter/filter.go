@@ -1,2 +1,24 @@
 // Package filter provides a set of useful [go.luke.ph/slogic.Filter] implementations.
+//
+// # Attribute Paths
+//
+// The attribute filters, such as [IfAttrEquals], identify an attribute by a dot-separated path.
+// Each segment of the path names either a group added via [slog.Logger.WithGroup],
+// or an attribute whose value is a group, such as one constructed with [slog.Group].
+// For example, the path "http.status" identifies the "status" attribute of both
+//
+//	logger.Info("Handled request", slog.Group("http", "status", 500))
+//	logger.WithGroup("http").Info("Handled request", "status", 500)
+//
+// A dot that is part of a key is escaped with a backslash,
+// so the path `http\.status` identifies a single attribute with the key "http.status".
+// A backslash that is part of a key may likewise be escaped as `\\`.
 package filter
+
+import "go.luke.ph/slogic"
+
+// describe returns the given filter,
+// described by the name of its constructor and the arguments it was called with.
+func describe(name string, filter slogic.Filter, args ...any) slogic.Filter {
+	return slogic.Describe(filter, slogic.Description{Name: name, Args: args})
+}
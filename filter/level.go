@@ -10,23 +10,34 @@ import (
 // IfLevelEquals returns a [slogic.Filter] that returns true if
 // the record's Level is equivalent to the given level.
 func IfLevelEquals(level slog.Level) slogic.Filter {
-	return func(_ context.Context, r slog.Record) bool {
-		return r.Level == level
-	}
+	return ifLevel("IfLevelEquals", level, func(l slog.Level) bool {
+		return l == level
+	})
 }
 
 // IfLevelAtLeast returns a [slogic.Filter] that returns true if
 // the record's Level is at least the given level.
 func IfLevelAtLeast(level slog.Level) slogic.Filter {
-	return func(_ context.Context, r slog.Record) bool {
-		return r.Level >= level
-	}
+	return ifLevel("IfLevelAtLeast", level, func(l slog.Level) bool {
+		return l >= level
+	})
 }
 
 // IfLevelAtMost returns a [slogic.Filter] that returns true if
 // the record's Level is at most the given level.
 func IfLevelAtMost(level slog.Level) slogic.Filter {
-	return func(_ context.Context, r slog.Record) bool {
-		return r.Level <= level
-	}
+	return ifLevel("IfLevelAtMost", level, func(l slog.Level) bool {
+		return l <= level
+	})
+}
+
+// ifLevel returns a [slogic.Filter] that depends on the record's Level alone,
+// and describes itself as such so that [slogic.Handler] can skip disabled levels.
+func ifLevel(name string, level slog.Level, predicate func(slog.Level) bool) slogic.Filter {
+	return slogic.Describe(
+		func(_ context.Context, r slog.Record) bool {
+			return predicate(r.Level)
+		},
+		slogic.Description{Name: name, Args: []any{level}, Level: predicate},
+	)
 }
@@ -0,0 +1,497 @@
+package filter
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.luke.ph/slogic"
+)
+
+// Parse compiles the given filter expression into a [slogic.Filter].
+//
+// An expression combines comparisons with the logical operators
+// "&&" ([slogic.And]), "||" ([slogic.Or]) and "!" ([slogic.Not]), grouped with parentheses:
+//
+//	level >= WARN && !(msg ~ /health/) && attr.latency_ms > 100
+//
+// The left-hand side of each comparison is one of:
+//
+//   - level, compared to a level such as DEBUG, INFO+2 or -4,
+//     with any of ==, !=, <, <=, > and >=.
+//   - msg, compared to a string with == or !=, to a substring with contains,
+//     or to a regular expression with ~ or !~.
+//   - time, compared to an RFC 3339 time string with <, <=, > or >=.
+//   - attr.PATH, where PATH is an attribute path ([Attribute Paths]),
+//     compared with any of the operators above, or standing alone to test whether the attribute exists.
+//
+// Strings are double-quoted Go string literals, and regular expressions are delimited by slashes,
+// with \/ denoting a literal slash and \\ a literal backslash; strings are also accepted as regular expressions.
+// Attribute values may also be numbers or the booleans true and false.
+//
+// If the expression is invalid, Parse returns a [*SyntaxError] locating the problem.
+func Parse(expr string) (slogic.Filter, error) {
+	p := &parser{input: expr}
+	p.next()
+	filter := p.parseOr()
+	if p.err == nil && p.tok.kind != tokenEOF {
+		p.errorf(p.tok.pos, "unexpected %s", p.tok)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return filter, nil
+}
+
+// A SyntaxError describes an invalid filter expression passed to [Parse].
+type SyntaxError struct {
+	// Offset is the byte offset in the expression at which the error occurred.
+	Offset int
+	// Msg describes the error.
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("filter: syntax error at column %d: %s", e.Offset+1, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenRegexp
+	tokenNumber
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	text string // the source text, or the unquoted value of a string or regular expression
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return "string " + strconv.Quote(t.text)
+	case tokenRegexp:
+		return "regular expression /" + t.text + "/"
+	}
+	return strconv.Quote(t.text)
+}
+
+type parser struct {
+	input string
+	pos   int
+	tok   token
+	err   *SyntaxError
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) {
+	if p.err == nil {
+		p.err = &SyntaxError{Offset: pos, Msg: fmt.Sprintf(format, args...)}
+	}
+	// Skips any remaining input so that parsing unwinds promptly...
+	p.pos = len(p.input)
+	p.tok = token{kind: tokenEOF, pos: p.pos}
+}
+
+// next scans the next token into p.tok.
+func (p *parser) next() {
+	for p.pos < len(p.input) && isSpace(p.input[p.pos]) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.input) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return
+	}
+
+	switch c := p.input[p.pos]; {
+	case c == '"':
+		end := p.pos + 1
+		for end < len(p.input) && p.input[end] != '"' {
+			if p.input[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.input) {
+			p.errorf(start, "unterminated string")
+			return
+		}
+		s, err := strconv.Unquote(p.input[start : end+1])
+		if err != nil {
+			p.errorf(start, "invalid string %s", p.input[start:end+1])
+			return
+		}
+		p.pos = end + 1
+		p.tok = token{kind: tokenString, pos: start, text: s}
+	case c == '/':
+		var re strings.Builder
+		end := p.pos + 1
+		for ; end < len(p.input) && p.input[end] != '/'; end++ {
+			if p.input[end] == '\\' && end+1 < len(p.input) {
+				// Skips the escaped character, so that e.g. /a\\/ ends with a literal backslash,
+				// unescaping only \/ and leaving any other escape to the regular expression...
+				if end++; p.input[end] != '/' {
+					re.WriteByte('\\')
+				}
+			}
+			re.WriteByte(p.input[end])
+		}
+		if end >= len(p.input) {
+			p.errorf(start, "unterminated regular expression")
+			return
+		}
+		p.pos = end + 1
+		p.tok = token{kind: tokenRegexp, pos: start, text: re.String()}
+	case c == '-' || c == '+' || isDigit(c):
+		end := p.pos + 1
+		for end < len(p.input) && (isWord(p.input[end]) || p.input[end] == '.') {
+			end++
+		}
+		p.pos = end
+		p.tok = token{kind: tokenNumber, pos: start, text: p.input[start:end]}
+	case isWord(c):
+		end := p.pos
+		for end < len(p.input) {
+			if p.input[end] == '\\' && end+1 < len(p.input) {
+				end += 2
+				continue
+			}
+			if !isWord(p.input[end]) && p.input[end] != '.' && p.input[end] != '+' && p.input[end] != '-' {
+				break
+			}
+			end++
+		}
+		p.pos = end
+		p.tok = token{kind: tokenIdent, pos: start, text: p.input[start:end]}
+	default:
+		for _, op := range []string{"&&", "||", "==", "!=", "!~", "<=", ">=", "!", "~", "<", ">", "(", ")"} {
+			if strings.HasPrefix(p.input[p.pos:], op) {
+				p.pos += len(op)
+				p.tok = token{kind: tokenOp, pos: start, text: op}
+				return
+			}
+		}
+		r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+		p.errorf(start, "unexpected character %q", r)
+	}
+}
+
+func isSpace(c byte) bool { return c < utf8.RuneSelf && unicode.IsSpace(rune(c)) }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+func isWord(c byte) bool {
+	return c == '_' || isDigit(c) || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c >= utf8.RuneSelf
+}
+
+func (p *parser) isOp(op string) bool {
+	return p.tok.kind == tokenOp && p.tok.text == op
+}
+
+func (p *parser) parseOr() slogic.Filter {
+	filters := []slogic.Filter{p.parseAnd()}
+	for p.isOp("||") {
+		p.next()
+		filters = append(filters, p.parseAnd())
+	}
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	return slogic.Or(filters...)
+}
+
+func (p *parser) parseAnd() slogic.Filter {
+	filters := []slogic.Filter{p.parseUnary()}
+	for p.isOp("&&") {
+		p.next()
+		filters = append(filters, p.parseUnary())
+	}
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	return slogic.And(filters...)
+}
+
+func (p *parser) parseUnary() slogic.Filter {
+	switch {
+	case p.isOp("!"):
+		p.next()
+		return slogic.Not(p.parseUnary())
+	case p.isOp("("):
+		open := p.tok.pos
+		p.next()
+		filter := p.parseOr()
+		if !p.isOp(")") {
+			if p.err == nil {
+				p.errorf(p.tok.pos, "expected \")\" to close \"(\" at column %d, found %s", open+1, p.tok)
+			}
+			return nil
+		}
+		p.next()
+		return filter
+	case p.tok.kind == tokenIdent:
+		return p.parseComparison()
+	}
+	p.errorf(p.tok.pos, "expected comparison, found %s", p.tok)
+	return nil
+}
+
+func (p *parser) parseComparison() slogic.Filter {
+	subject := p.tok
+	p.next()
+
+	switch {
+	case subject.text == "level":
+		return p.parseLevel()
+	case subject.text == "msg":
+		return p.parseMessage()
+	case subject.text == "time":
+		return p.parseTime()
+	case strings.HasPrefix(subject.text, "attr."):
+		return p.parseAttr(subject.text[len("attr."):])
+	}
+	p.errorf(subject.pos, "unknown field %s, expected level, msg, time or attr.PATH", subject)
+	return nil
+}
+
+// operator consumes the current token if it is one of the given operators.
+func (p *parser) operator(field string, ops ...string) (string, bool) {
+	op := p.tok
+	if (op.kind == tokenOp || op.kind == tokenIdent) && slices.Contains(ops, op.text) {
+		p.next()
+		return op.text, true
+	}
+	p.errorf(op.pos, "expected %s operator (%s), found %s", field, strings.Join(ops, " "), op)
+	return "", false
+}
+
+func (p *parser) parseLevel() slogic.Filter {
+	op, ok := p.operator("level", "==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return nil
+	}
+	operand := p.tok
+	var level slog.Level
+	if operand.kind != tokenIdent && operand.kind != tokenNumber {
+		p.errorf(operand.pos, "expected level, found %s", operand)
+		return nil
+	}
+	if n, err := strconv.Atoi(operand.text); err == nil {
+		level = slog.Level(n)
+	} else if err := level.UnmarshalText([]byte(operand.text)); err != nil {
+		p.errorf(operand.pos, "invalid level %s", operand)
+		return nil
+	}
+	p.next()
+
+	switch op {
+	case "==":
+		return IfLevelEquals(level)
+	case "!=":
+		return slogic.Not(IfLevelEquals(level))
+	case "<":
+		return slogic.Not(IfLevelAtLeast(level))
+	case "<=":
+		return IfLevelAtMost(level)
+	case ">":
+		return slogic.Not(IfLevelAtMost(level))
+	default:
+		return IfLevelAtLeast(level)
+	}
+}
+
+func (p *parser) parseMessage() slogic.Filter {
+	op, ok := p.operator("msg", "==", "!=", "contains", "~", "!~")
+	if !ok {
+		return nil
+	}
+
+	switch op {
+	case "~", "!~":
+		pattern, ok := p.regexp()
+		if !ok {
+			return nil
+		}
+		if op == "!~" {
+			return slogic.Not(IfMessageMatches(pattern))
+		}
+		return IfMessageMatches(pattern)
+	}
+
+	s, ok := p.string()
+	if !ok {
+		return nil
+	}
+	switch op {
+	case "==":
+		return IfMessageEquals(s)
+	case "!=":
+		return slogic.Not(IfMessageEquals(s))
+	default:
+		return IfMessageContains(s)
+	}
+}
+
+func (p *parser) parseTime() slogic.Filter {
+	op, ok := p.operator("time", "<", "<=", ">", ">=")
+	if !ok {
+		return nil
+	}
+	pos := p.tok.pos
+	s, ok := p.string()
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		p.errorf(pos, "invalid RFC 3339 time %q", s)
+		return nil
+	}
+
+	switch op {
+	case "<":
+		return IfTimeBefore(t)
+	case "<=":
+		return slogic.Not(IfTimeAfter(t))
+	case ">":
+		return IfTimeAfter(t)
+	default:
+		return slogic.Not(IfTimeBefore(t))
+	}
+}
+
+func (p *parser) parseAttr(key string) slogic.Filter {
+	if key == "" {
+		p.errorf(p.tok.pos, "expected attribute path after \"attr.\"")
+		return nil
+	}
+	ops := []string{"==", "!=", "<", "<=", ">", ">=", "contains", "~", "!~"}
+	if p.tok.kind != tokenOp && p.tok.kind != tokenIdent || !slices.Contains(ops, p.tok.text) {
+		return IfAttrExists(key)
+	}
+
+	op, ok := p.operator("attribute", ops...)
+	if !ok {
+		return nil
+	}
+
+	switch op {
+	case "~", "!~":
+		pattern, ok := p.regexp()
+		if !ok {
+			return nil
+		}
+		if op == "!~" {
+			return slogic.Not(IfAttrMatches(key, pattern))
+		}
+		return IfAttrMatches(key, pattern)
+	case "contains":
+		s, ok := p.string()
+		if !ok {
+			return nil
+		}
+		return IfAttrContains(key, s)
+	case "<", "<=", ">", ">=":
+		operand := p.tok
+		if operand.kind != tokenNumber {
+			p.errorf(operand.pos, "expected number, found %s", operand)
+			return nil
+		}
+		n, ok := p.number()
+		if !ok {
+			return nil
+		}
+		switch op {
+		case "<":
+			return IfAttrLessThan(key, n)
+		case "<=":
+			return IfAttrBetween(key, math.Inf(-1), n)
+		case ">":
+			return IfAttrGreaterThan(key, n)
+		default:
+			return IfAttrBetween(key, n, math.Inf(1))
+		}
+	}
+
+	value, ok := p.value()
+	if !ok {
+		return nil
+	}
+	if op == "!=" {
+		return slogic.Not(IfAttrEquals(key, value))
+	}
+	return IfAttrEquals(key, value)
+}
+
+func (p *parser) string() (string, bool) {
+	if p.tok.kind != tokenString {
+		p.errorf(p.tok.pos, "expected string, found %s", p.tok)
+		return "", false
+	}
+	s := p.tok.text
+	p.next()
+	return s, true
+}
+
+func (p *parser) regexp() (string, bool) {
+	operand := p.tok
+	if operand.kind != tokenRegexp && operand.kind != tokenString {
+		p.errorf(operand.pos, "expected regular expression, found %s", operand)
+		return "", false
+	}
+	if _, err := regexp.Compile(operand.text); err != nil {
+		p.errorf(operand.pos, "invalid regular expression: %v", err)
+		return "", false
+	}
+	p.next()
+	return operand.text, true
+}
+
+func (p *parser) value() (any, bool) {
+	operand := p.tok
+	var value any
+	switch {
+	case operand.kind == tokenString:
+		value = operand.text
+	case operand.kind == tokenNumber:
+		return p.number()
+	case operand.kind == tokenIdent && (operand.text == "true" || operand.text == "false"):
+		value = operand.text == "true"
+	default:
+		p.errorf(operand.pos, "expected string, number or boolean, found %s", operand)
+		return nil, false
+	}
+	p.next()
+	return value, true
+}
+
+// number consumes the current token as an int64, or else a float64,
+// accepting the same syntax as Go's integer and floating-point literals.
+func (p *parser) number() (any, bool) {
+	operand := p.tok
+	var n any
+	if i, err := strconv.ParseInt(operand.text, 0, 64); err == nil {
+		n = i
+	} else if f, err := strconv.ParseFloat(operand.text, 64); err == nil {
+		n = f
+	} else {
+		p.errorf(operand.pos, "invalid number %s", operand)
+		return nil, false
+	}
+	p.next()
+	return n, true
+}
@@ -0,0 +1,174 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := func(level slog.Level, msg string, attrs ...slog.Attr) slog.Record {
+		r := slog.NewRecord(now, level, msg, 0)
+		r.AddAttrs(attrs...)
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		expr   string
+		record slog.Record
+		want   bool
+	}{
+		{
+			name:   "level",
+			expr:   "level >= WARN",
+			record: record(slog.LevelError, ""),
+			want:   true,
+		},
+		{
+			name:   "level offset",
+			expr:   "level > INFO+4",
+			record: record(slog.LevelWarn, ""),
+			want:   false,
+		},
+		{
+			name:   "level number",
+			expr:   "level != -4",
+			record: record(slog.LevelDebug, ""),
+			want:   false,
+		},
+		{
+			name:   "message regexp",
+			expr:   `msg ~ /^GET \/health/`,
+			record: record(slog.LevelInfo, "GET /health"),
+			want:   true,
+		},
+		{
+			name:   "message regexp backslash",
+			expr:   `msg ~ /C:\\/ && msg ~ /\\d/`,
+			record: record(slog.LevelInfo, `C:\d`),
+			want:   true,
+		},
+		{
+			name:   "message contains",
+			expr:   `msg contains "health"`,
+			record: record(slog.LevelInfo, "GET /healthz"),
+			want:   true,
+		},
+		{
+			name:   "message not equals",
+			expr:   `msg != "GET /health"`,
+			record: record(slog.LevelInfo, "GET /health"),
+			want:   false,
+		},
+		{
+			name:   "time",
+			expr:   `time >= "2025-01-01T12:00:00Z" && time < "2025-01-02T00:00:00Z"`,
+			record: record(slog.LevelInfo, ""),
+			want:   true,
+		},
+		{
+			name:   "attr exists",
+			expr:   "attr.latency_ms",
+			record: record(slog.LevelInfo, "", slog.Int("latency_ms", 1)),
+			want:   true,
+		},
+		{
+			name:   "attr number",
+			expr:   "attr.latency_ms > 100",
+			record: record(slog.LevelInfo, "", slog.Float64("latency_ms", 100.5)),
+			want:   true,
+		},
+		{
+			name:   "attr number inclusive",
+			expr:   "attr.latency_ms >= 100 && attr.latency_ms <= 100.5",
+			record: record(slog.LevelInfo, "", slog.Uint64("latency_ms", 100)),
+			want:   true,
+		},
+		{
+			name:   "attr number hexadecimal",
+			expr:   "attr.flags > 0x10 && attr.flags == 0x11",
+			record: record(slog.LevelInfo, "", slog.Int("flags", 17)),
+			want:   true,
+		},
+		{
+			name:   "attr number missing",
+			expr:   "attr.latency_ms <= 100",
+			record: record(slog.LevelInfo, ""),
+			want:   false,
+		},
+		{
+			name:   "attr equals",
+			expr:   `attr.http.status == 500 && attr.http.method != "GET"`,
+			record: record(slog.LevelInfo, "", slog.Group("http", "status", 500, "method", "POST")),
+			want:   true,
+		},
+		{
+			name:   "attr boolean",
+			expr:   "attr.ok == true",
+			record: record(slog.LevelInfo, "", slog.Bool("ok", true)),
+			want:   true,
+		},
+		{
+			name:   "precedence",
+			expr:   `level == ERROR || level == WARN && attr.latency_ms`,
+			record: record(slog.LevelError, ""),
+			want:   true,
+		},
+		{
+			name:   "example",
+			expr:   "level >= WARN && !(msg ~ /health/) && attr.latency_ms > 100",
+			record: record(slog.LevelWarn, "Executed slow database query", slog.Int("latency_ms", 250)),
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := filter(context.Background(), tt.record)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		expr   string
+		offset int
+	}{
+		{expr: "", offset: 0},
+		{expr: "level >= LOUD", offset: 9},
+		{expr: "level >= WARN &&", offset: 16},
+		{expr: "(level >= WARN", offset: 14},
+		{expr: "level >= WARN)", offset: 13},
+		{expr: "msg ~ /[/", offset: 6},
+		{expr: `msg ~ /a\/`, offset: 6},
+		{expr: `msg == "unterminated`, offset: 7},
+		{expr: "severity >= WARN", offset: 0},
+		{expr: `time > "yesterday"`, offset: 7},
+		{expr: "attr.latency_ms > fast", offset: 18},
+		{expr: "level # WARN", offset: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("got: %v, want: *SyntaxError", err)
+			}
+			if syntaxErr.Offset != tt.offset {
+				t.Errorf("got: %d (%v), want: %d", syntaxErr.Offset, err, tt.offset)
+			}
+		})
+	}
+}
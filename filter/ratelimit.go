@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+// defaultMaxKeys is the default of [RateLimitOptions.MaxKeys].
+const defaultMaxKeys = 1024
+
+// A KeyFunc returns the key that groups the given record with others, e.g. for [RateLimit].
+type KeyFunc func(context.Context, slog.Record) string
+
+// ByMessage is a [KeyFunc] that groups records by their Message.
+func ByMessage(_ context.Context, r slog.Record) string {
+	return r.Message
+}
+
+// ByCallsite is a [KeyFunc] that groups records by their PC,
+// i.e. the call to the [slog.Logger] method that created them.
+func ByCallsite(_ context.Context, r slog.Record) string {
+	return strconv.FormatUint(uint64(r.PC), 16)
+}
+
+// ByAttr returns a [KeyFunc] that groups records by the value of
+// their [slog.Attr] with the given key ([Attribute Paths]).
+// Records without the attribute are grouped together.
+func ByAttr(key string) KeyFunc {
+	path := splitPath(key)
+	return func(ctx context.Context, r slog.Record) string {
+		var value string
+		anyAttr(ctx, r, path, func(attr slog.Attr) bool {
+			value = attr.Value.String()
+			return true
+		})
+		return value
+	}
+}
+
+// RateLimit returns a [slogic.Filter] that returns false for up to
+// perSecond records per second per key, with bursts of up to burst records,
+// and returns true for records exceeding the limit.
+//
+// The records are grouped into keys by the given [KeyFunc], such as [ByMessage],
+// [ByCallsite] or [ByAttr]; if it is nil, all records share a single limit.
+// Each key is allotted a token bucket, of which RateLimit retains up to 1024,
+// evicting the least recently used; see [RateLimitWithOptions] to configure this.
+func RateLimit(perSecond float64, burst int, key KeyFunc) slogic.Filter {
+	return RateLimitWithOptions(perSecond, burst, key, nil)
+}
+
+// RateLimitOptions are options for [RateLimitWithOptions].
+type RateLimitOptions struct {
+	// MaxKeys is the maximum number of keys whose token buckets are retained.
+	// Once exceeded, the least recently used key's bucket is evicted,
+	// such that the key's next record is allotted a full bucket.
+	// If zero, it defaults to 1024.
+	MaxKeys int
+
+	// Now returns the current time. If nil, it defaults to [time.Now].
+	Now func() time.Time
+}
+
+// RateLimitWithOptions is like [RateLimit], using the given options.
+// A nil opts is equivalent to the zero [RateLimitOptions].
+func RateLimitWithOptions(perSecond float64, burst int, key KeyFunc, opts *RateLimitOptions) slogic.Filter {
+	l := &rateLimiter{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		maxKeys:   defaultMaxKeys,
+		now:       time.Now,
+		buckets:   make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+	if opts != nil {
+		if opts.MaxKeys > 0 {
+			l.maxKeys = opts.MaxKeys
+		}
+		if opts.Now != nil {
+			l.now = opts.Now
+		}
+	}
+
+	return describe("RateLimit", func(ctx context.Context, r slog.Record) bool {
+		var k string
+		if key != nil {
+			k = key(ctx, r)
+		}
+		return !l.allow(k)
+	}, perSecond, burst)
+}
+
+type rateLimiter struct {
+	perSecond float64
+	burst     float64
+	maxKeys   int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     *list.List // of *bucket, most recently used first
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// allow reports whether the given key's bucket has a token to spend, spending it if so.
+func (l *rateLimiter) allow(key string) bool {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var b *bucket
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		b = e.Value.(*bucket)
+		// A clock that steps backwards neither refills nor rewinds the bucket.
+		elapsed := max(0, now.Sub(b.last))
+		b.tokens = min(l.burst, b.tokens+elapsed.Seconds()*l.perSecond)
+		b.last = b.last.Add(elapsed)
+	} else {
+		if l.lru.Len() >= l.maxKeys {
+			delete(l.buckets, l.lru.Remove(l.lru.Back()).(*bucket).key)
+		}
+		b = &bucket{key: key, tokens: l.burst, last: now}
+		l.buckets[key] = l.lru.PushFront(b)
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,144 @@
+package filter
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := RateLimitWithOptions(2, 3, ByMessage, &RateLimitOptions{
+		Now: func() time.Time { return now },
+	})
+
+	kept := func(msg string, n int) []bool {
+		var got []bool
+		for range n {
+			got = append(got, !f(context.Background(), slog.NewRecord(now, slog.LevelInfo, msg, 0)))
+		}
+		return got
+	}
+
+	// Allows a burst of 3...
+	if got, want := kept("a", 4), []bool{true, true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	// ... independently per key...
+	if got, want := kept("b", 1), []bool{true}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	// ... refilling at 2 per second...
+	now = now.Add(time.Second)
+	if got, want := kept("a", 3), []bool{true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	// ... up to the burst.
+	now = now.Add(time.Hour)
+	if got, want := kept("a", 4), []bool{true, true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestRateLimitClockSkew(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := RateLimitWithOptions(1, 2, nil, &RateLimitOptions{
+		Now: func() time.Time { return now },
+	})
+	kept := func(n int) []bool {
+		var got []bool
+		for range n {
+			got = append(got, !f(context.Background(), slog.NewRecord(now, slog.LevelInfo, "message", 0)))
+		}
+		return got
+	}
+
+	if got, want := kept(1), []bool{true}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	// A clock stepping backwards neither drains the bucket...
+	now = now.Add(-time.Minute)
+	if got, want := kept(2), []bool{true, false}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	// ... nor refills it until it catches up.
+	now = now.Add(time.Minute + time.Second)
+	if got, want := kept(2), []bool{true, false}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestRateLimitMaxKeys(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := RateLimitWithOptions(1, 1, ByMessage, &RateLimitOptions{
+		MaxKeys: 2,
+		Now:     func() time.Time { return now },
+	})
+
+	kept := func(msg string) bool {
+		return !f(context.Background(), slog.NewRecord(now, slog.LevelInfo, msg, 0))
+	}
+
+	var got []bool
+	for _, msg := range []string{"a", "b", "a", "c", "a", "b"} {
+		got = append(got, kept(msg))
+	}
+	// "b" is evicted by "c", as "a" was used more recently, so its bucket is full once again...
+	if want := []bool{true, true, false, true, false, true}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestKeyFuncs(t *testing.T) {
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "message", 0x2a)
+	r.AddAttrs(slog.Group("tenant", slog.String("id", "acme")))
+
+	tests := []struct {
+		name string
+		key  KeyFunc
+		want string
+	}{
+		{name: "message", key: ByMessage, want: "message"},
+		{name: "callsite", key: ByCallsite, want: "2a"},
+		{name: "attr", key: ByAttr("tenant.id"), want: "acme"},
+		{name: "missing attr", key: ByAttr("user.id"), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key(context.Background(), r); got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitConcurrent(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := RateLimitWithOptions(1, 100, nil, &RateLimitOptions{
+		Now: func() time.Time { return now },
+	})
+
+	var kept atomic.Int64
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				if !f(context.Background(), slog.NewRecord(now, slog.LevelInfo, "message", 0)) {
+					kept.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := kept.Load(), int64(100); got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
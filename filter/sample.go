@@ -0,0 +1,73 @@
+package filter
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"go.luke.ph/slogic"
+)
+
+// SampleRate returns a [slogic.Filter] that returns false for
+// the given fraction of records, chosen at random, and returns true for the rest.
+// For example, SampleRate(0.1) keeps approximately one in ten records.
+func SampleRate(rate float64) slogic.Filter {
+	return describe("SampleRate", func(_ context.Context, _ slog.Record) bool {
+		return rand.Float64() >= rate
+	}, rate)
+}
+
+// SampleRateWithSource is like [SampleRate], but draws random numbers from the given source,
+// e.g. one with a fixed seed, so that the records it keeps are deterministic.
+// Access to the source is serialized, so it needn't be safe for concurrent use.
+func SampleRateWithSource(rate float64, src rand.Source) slogic.Filter {
+	var mu sync.Mutex
+	r := rand.New(src)
+	return describe("SampleRateWithSource", func(_ context.Context, _ slog.Record) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return r.Float64() >= rate
+	}, rate)
+}
+
+// EveryNth returns a [slogic.Filter] that returns false for
+// every nth record, starting with the first, and returns true for the rest.
+// For example, EveryNth(100) keeps the 1st, 101st, 201st, ... records,
+// whereas EveryNth(0) and EveryNth(1) keep every record.
+func EveryNth(n uint64) slogic.Filter {
+	var count atomic.Uint64
+	return describe("EveryNth", func(_ context.Context, _ slog.Record) bool {
+		return n > 1 && (count.Add(1)-1)%n != 0
+	}, n)
+}
+
+// SampleByAttr returns a [slogic.Filter] that returns false for
+// the given fraction of the values of the [slog.Attr] with the given key ([Attribute Paths]),
+// and returns true for the rest.
+//
+// Whether a record is kept depends solely on the attribute's value, which is hashed
+// deterministically, so that every process sampling e.g. a "trace_id" attribute at the same rate
+// keeps and drops the same traces, regardless of restarts.
+// Records without the attribute are kept.
+func SampleByAttr(key string, rate float64) slogic.Filter {
+	return describe("SampleByAttr", ifAttr(key, func(attr slog.Attr) bool {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(attr.Value.String()))
+		// Maps the hash uniformly onto [0, 1)...
+		return float64(mix(h.Sum64())>>11)/(1<<53) >= rate
+	}), key, rate)
+}
+
+// mix is the 64-bit finalizer of MurmurHash3, which spreads the influence of every bit of h
+// across all of the bits of the result, as FNV-1a alone does not for its most significant bits.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
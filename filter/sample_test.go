@@ -0,0 +1,141 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+func TestSampleRateWithSource(t *testing.T) {
+	tests := []struct {
+		name string
+		rate float64
+		want int
+	}{
+		{name: "none", rate: 0, want: 0},
+		{name: "some", rate: 0.1, want: 91},
+		{name: "all", rate: 1, want: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testKept(SampleRateWithSource(tt.rate, rand.NewPCG(1, 2)), 1000)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleRate(t *testing.T) {
+	got := testKept(SampleRate(0.5), 10000)
+	if got < 4500 || got > 5500 {
+		t.Errorf("got: %v, want: ~5000", got)
+	}
+}
+
+func TestEveryNth(t *testing.T) {
+	tests := []struct {
+		name string
+		n    uint64
+		want int
+	}{
+		{name: "0", n: 0, want: 1000},
+		{name: "1", n: 1, want: 1000},
+		{name: "3", n: 3, want: 334},
+		{name: "100", n: 100, want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testKept(EveryNth(tt.n), 1000)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+
+	f := EveryNth(2)
+	var got []bool
+	for range 4 {
+		got = append(got, f(context.Background(), slog.Record{}))
+	}
+	if want := []bool{false, true, false, true}; !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestSampleByAttr(t *testing.T) {
+	f := SampleByAttr("trace_id", 0.25)
+	g := SampleByAttr("trace.id", 0.25)
+
+	kept := 0
+	for i := range 1000 {
+		id := fmt.Sprintf("%032x", i)
+		got := testAttr(f, []slog.Attr{slog.String("trace_id", id)})
+		if !got {
+			kept++
+		}
+		// The decision is consistent, including across filters and attribute paths...
+		if again := testAttr(f, []slog.Attr{slog.String("trace_id", id)}); again != got {
+			t.Fatalf("got: %v, want: %v", again, got)
+		}
+		if other := testAttr(g, []slog.Attr{slog.Group("trace", slog.String("id", id))}); other != got {
+			t.Fatalf("got: %v, want: %v", other, got)
+		}
+	}
+	if kept < 200 || kept > 300 {
+		t.Errorf("got: %v, want: ~250", kept)
+	}
+
+	// Decisions are stable across processes and releases...
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: "4bf92f3577b34da6a3ce929d0e0e4736", want: true},
+		{id: "00f067aa0ba902b7", want: false},
+		{id: "request-1", want: true},
+	}
+	for _, tt := range tests {
+		if got := testAttr(f, []slog.Attr{slog.String("trace_id", tt.id)}); got != tt.want {
+			t.Errorf("%s got: %v, want: %v", tt.id, got, tt.want)
+		}
+	}
+
+	if testAttr(f, nil) {
+		t.Error("got: true, want: false")
+	}
+}
+
+// testKept concurrently evaluates the filter for the given number of records,
+// returning the number of records kept.
+func testKept(filter slogic.Filter, n int) int {
+	var (
+		mu   sync.Mutex
+		kept int
+		wg   sync.WaitGroup
+	)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range n / 10 {
+				if !filter(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "", 0)) {
+					mu.Lock()
+					kept++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return kept
+}
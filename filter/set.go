@@ -0,0 +1,174 @@
+package filter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+// defaultWatchInterval is the default of [WatchOptions.Interval].
+const defaultWatchInterval = 10 * time.Second
+
+// IfAttrIn returns a [slogic.Filter] that returns true if
+// the record's [slog.Attr] with the given key ([Attribute Paths]) is one of the given values.
+//
+// The attribute's value is compared by its string representation, per [slog.Value.String],
+// against a hash set of the values, so the filter remains fast for thousands of values,
+// unlike an [slogic.Or] of [IfAttrEquals] filters. See [IfAttrInSet] for sets that change over time.
+func IfAttrIn(key string, values ...string) slogic.Filter {
+	set := NewSet(values...)
+	return describe("IfAttrIn", ifAttr(key, func(attr slog.Attr) bool {
+		return set.Contains(attr.Value.String())
+	}), key, setValues(slices.Clone(values)))
+}
+
+// setValues are the values of [IfAttrIn], as described by [slogic.Inspect].
+type setValues []string
+
+// String summarizes the values, which may be too many to represent in full,
+// e.g. "3120 values" in the representation per [slogic.Filter.String].
+func (v setValues) String() string {
+	if len(v) == 1 {
+		return "1 value"
+	}
+	return fmt.Sprintf("%d values", len(v))
+}
+
+// IfAttrInSet returns a [slogic.Filter] that returns true if
+// the record's [slog.Attr] with the given key ([Attribute Paths]) is in the given set,
+// as per [IfAttrIn]. Changes to the set take effect immediately.
+func IfAttrInSet(key string, set *Set) slogic.Filter {
+	return describe("IfAttrInSet", ifAttr(key, func(attr slog.Attr) bool {
+		return set.Contains(attr.Value.String())
+	}), key)
+}
+
+// A Set is a set of strings, such as the attribute values of [IfAttrInSet].
+// It is safe for concurrent use, and can be replaced atomically, e.g. by [WatchSet].
+type Set struct {
+	values atomic.Pointer[map[string]struct{}]
+}
+
+// NewSet constructs a [*Set] holding the given values.
+func NewSet(values ...string) *Set {
+	s := new(Set)
+	s.Replace(values...)
+	return s
+}
+
+// Contains reports whether the set holds the given value.
+func (s *Set) Contains(value string) bool {
+	_, ok := (*s.values.Load())[value]
+	return ok
+}
+
+// Len returns the number of values the set holds.
+func (s *Set) Len() int {
+	return len(*s.values.Load())
+}
+
+// Replace atomically replaces the values the set holds with the given values.
+func (s *Set) Replace(values ...string) {
+	m := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		m[v] = struct{}{}
+	}
+	s.values.Store(&m)
+}
+
+// WatchOptions are options for [WatchSet].
+type WatchOptions struct {
+	// Interval is the interval at which the file is checked for changes.
+	// If zero, it defaults to 10 seconds.
+	Interval time.Duration
+
+	// OnError, if non-nil, is called with the errors encountered reloading the file.
+	// The set retains its previous values when the file cannot be reloaded.
+	OnError func(error)
+}
+
+// WatchSet constructs a [*Set] holding the values of the given file,
+// which lists one value per line, ignoring blank lines and lines starting with "#".
+//
+// Until the given context is done, the file is checked for changes to its modification time
+// or size at the interval per [WatchOptions.Interval], and the set is replaced upon any change.
+// A nil opts is equivalent to the zero [WatchOptions].
+func WatchSet(ctx context.Context, path string, opts *WatchOptions) (*Set, error) {
+	var o WatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultWatchInterval
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+	values, err := readSet(path)
+	if err != nil {
+		return nil, err
+	}
+	set := NewSet(values...)
+
+	go func() {
+		ticker := time.NewTicker(o.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			latest, err := os.Stat(path)
+			if err == nil && latest.ModTime().Equal(info.ModTime()) && latest.Size() == info.Size() {
+				continue
+			}
+			if err == nil {
+				var values []string
+				if values, err = readSet(path); err == nil {
+					set.Replace(values...)
+					info = latest
+					continue
+				}
+			}
+			if o.OnError != nil {
+				o.OnError(err)
+			}
+		}
+	}()
+	return set, nil
+}
+
+// readSet reads the values of the given file, one per line.
+func readSet(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("filter: %w", err)
+	}
+	defer f.Close()
+
+	var values []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values = append(values, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("filter: reading %s: %w", path, err)
+	}
+	return values, nil
+}
@@ -0,0 +1,154 @@
+package filter
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIfAttrIn(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs []slog.Attr
+		want  bool
+	}{
+		{
+			name:  "false",
+			attrs: []slog.Attr{slog.String("FOO", "BAZ")},
+			want:  false,
+		},
+		{
+			name:  "true",
+			attrs: []slog.Attr{slog.String("FOO", "BAR")},
+			want:  true,
+		},
+		{
+			name:  "number",
+			attrs: []slog.Attr{slog.Int("FOO", 42)},
+			want:  true,
+		},
+		{
+			name:  "missing",
+			attrs: []slog.Attr{slog.String("BAR", "BAR")},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testAttr(IfAttrIn("FOO", "BAR", "42"), tt.attrs)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfAttrInSet(t *testing.T) {
+	set := NewSet("BAR")
+	f := IfAttrInSet("FOO", set)
+
+	if got := testAttr(f, []slog.Attr{slog.String("FOO", "BAZ")}); got {
+		t.Errorf("got: %v, want: %v", got, false)
+	}
+	set.Replace("BAR", "BAZ")
+	if got := testAttr(f, []slog.Attr{slog.String("FOO", "BAZ")}); !got {
+		t.Errorf("got: %v, want: %v", got, true)
+	}
+	if got, want := set.Len(), 2; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestWatchSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.txt")
+	if err := os.WriteFile(path, []byte("# Synthetic monitors\nuser_1\n\n  user_2  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	set, err := WatchSet(ctx, path, &WatchOptions{
+		Interval: time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !set.Contains("user_1") || !set.Contains("user_2") || set.Len() != 2 {
+		t.Fatalf("got: %d values, want: user_1 and user_2", set.Len())
+	}
+
+	if err := os.WriteFile(path, []byte("user_3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return set.Contains("user_3") })
+	if set.Contains("user_1") {
+		t.Error("got: user_1, want: user_3 only")
+	}
+
+	// Retains the values when the file cannot be reloaded...
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("got: no error, want: error")
+	}
+	if !set.Contains("user_3") {
+		t.Error("got: no user_3, want: user_3")
+	}
+}
+
+func TestWatchSetError(t *testing.T) {
+	if _, err := WatchSet(context.Background(), filepath.Join(t.TempDir(), "missing.txt"), nil); err == nil {
+		t.Error("got: nil, want: error")
+	}
+}
+
+// waitFor polls the given condition until it holds, failing the test after 5 seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestIfAttrInString(t *testing.T) {
+	values := make([]string, 3120)
+	for i := range values {
+		values[i] = "user_" + strconv.Itoa(i)
+	}
+	f := IfAttrIn("user_id", values...)
+
+	if got, want := f.String(), "IfAttrIn(user_id, 3120 values)"; got != want {
+		t.Errorf("got: %s, want: %s", got, want)
+	}
+	if got, want := IfAttrIn("user_id", "user_1").String(), "IfAttrIn(user_id, 1 value)"; got != want {
+		t.Errorf("got: %s, want: %s", got, want)
+	}
+
+	// The values are still described in full by the config...
+	c, err := ConfigOf(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(c.IfAttrIn.Values); got != len(values) {
+		t.Errorf("got: %d values, want: %d", got, len(values))
+	}
+}
@@ -0,0 +1,107 @@
+package filter
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.luke.ph/slogic"
+)
+
+// frames caches the frames resolved from records' PCs, which identify a fixed set of callsites.
+var frames sync.Map // of uintptr to runtime.Frame
+
+// IfSourceFile returns a [slogic.Filter] that returns true if
+// the record was logged from a source file matching the given glob pattern, per [path.Match].
+//
+// A pattern starting with "/" is matched against the file's full path,
+// whereas others are matched against as many of its trailing path elements as the pattern has,
+// so that e.g. "db/*.go" matches "/src/app/internal/db/query.go".
+func IfSourceFile(glob string) slogic.Filter {
+	n := strings.Count(glob, "/") + 1
+	return describe("IfSourceFile", ifSource(func(frame runtime.Frame) bool {
+		file := frame.File
+		if !strings.HasPrefix(glob, "/") {
+			file = trailingElems(file, n)
+		}
+		ok, _ := path.Match(glob, file)
+		return ok
+	}), glob)
+}
+
+// IfSourceFunction returns a [slogic.Filter] that returns true if
+// the record was logged from a function whose fully qualified name, such as
+// "github.com/acme/db.(*Conn).Query", matches the given regular expression.
+func IfSourceFunction(pattern string) slogic.Filter {
+	re := regexp.MustCompile(pattern)
+	return describe("IfSourceFunction", ifSource(func(frame runtime.Frame) bool {
+		return re.MatchString(frame.Function)
+	}), pattern)
+}
+
+// IfSourcePackage returns a [slogic.Filter] that returns true if
+// the record was logged from the package with the given import path,
+// or, if the import path ends with "/...", from that package or any package within it.
+func IfSourcePackage(importPath string) slogic.Filter {
+	prefix, recursive := strings.CutSuffix(importPath, "/...")
+	return describe("IfSourcePackage", ifSource(func(frame runtime.Frame) bool {
+		pkg := functionPackage(frame.Function)
+		return pkg == prefix || recursive && strings.HasPrefix(pkg, prefix+"/")
+	}), importPath)
+}
+
+// ifSource returns a [slogic.Filter] that returns true if the frame of the record's PC
+// satisfies the predicate, caching the result per PC.
+// Records without a PC are never matched.
+func ifSource(predicate func(runtime.Frame) bool) slogic.Filter {
+	var results sync.Map // of uintptr to bool
+	return func(_ context.Context, r slog.Record) bool {
+		if r.PC == 0 {
+			return false
+		}
+		if result, ok := results.Load(r.PC); ok {
+			return result.(bool)
+		}
+		result := predicate(frameOf(r.PC))
+		results.Store(r.PC, result)
+		return result
+	}
+}
+
+// frameOf returns the frame of the given PC, resolving it only once.
+func frameOf(pc uintptr) runtime.Frame {
+	if frame, ok := frames.Load(pc); ok {
+		return frame.(runtime.Frame)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	frames.Store(pc, frame)
+	return frame
+}
+
+// functionPackage returns the import path of the package of the given fully qualified function name.
+func functionPackage(function string) string {
+	// The package is delimited by the first dot after the last slash,
+	// e.g. "github.com/acme/db.(*Conn).Query", as the linker escapes any dots before it,
+	// e.g. "gopkg.in/yaml%2ev3.Unmarshal"...
+	i := strings.LastIndexByte(function, '/') + 1
+	if j := strings.IndexByte(function[i:], '.'); j >= 0 {
+		function = function[:i+j]
+	}
+	return strings.ReplaceAll(function, "%2e", ".")
+}
+
+// trailingElems returns the last n slash-separated elements of the given path.
+func trailingElems(p string, n int) string {
+	i := len(p)
+	for ; n > 0 && i > 0; n-- {
+		i = strings.LastIndexByte(p[:i], '/')
+		if i < 0 {
+			return p
+		}
+	}
+	return p[i+1:]
+}
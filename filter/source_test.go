@@ -0,0 +1,114 @@
+package filter
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"testing"
+	"time"
+
+	"go.luke.ph/slogic"
+)
+
+func TestIfSourceFile(t *testing.T) {
+	tests := []struct {
+		glob string
+		want bool
+	}{
+		{glob: "source_test.go", want: true},
+		{glob: "*_test.go", want: true},
+		{glob: "filter/source_*.go", want: true},
+		{glob: "slogic/filter/*.go", want: false},
+		{glob: "/*_test.go", want: false},
+		{glob: "source.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			if got := testSource(IfSourceFile(tt.glob), callerPC()); got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfSourceFunction(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{pattern: `^go\.luke\.ph/slogic/filter\.TestIfSourceFunction\.func1$`, want: true},
+		{pattern: `TestIfSource`, want: true},
+		{pattern: `^TestIfSource`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := testSource(IfSourceFunction(tt.pattern), callerPC()); got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfSourcePackage(t *testing.T) {
+	tests := []struct {
+		importPath string
+		want       bool
+	}{
+		{importPath: "go.luke.ph/slogic/filter", want: true},
+		{importPath: "go.luke.ph/slogic/...", want: true},
+		{importPath: "go.luke.ph/slogic/filter/...", want: true},
+		{importPath: "go.luke.ph/slogic", want: false},
+		{importPath: "go.luke.ph/slog/...", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importPath, func(t *testing.T) {
+			f := IfSourcePackage(tt.importPath)
+			// Evaluates twice, the second time from the cache...
+			for range 2 {
+				if got := testSource(f, callerPC()); got != tt.want {
+					t.Errorf("got: %v, want: %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	t.Run("no PC", func(t *testing.T) {
+		if got := testSource(IfSourcePackage("go.luke.ph/slogic/..."), 0); got {
+			t.Errorf("got: %v, want: %v", got, false)
+		}
+	})
+}
+
+func TestFunctionPackage(t *testing.T) {
+	tests := []struct {
+		function string
+		want     string
+	}{
+		{function: "main.main", want: "main"},
+		{function: "github.com/acme/db.(*Conn).Query", want: "github.com/acme/db"},
+		{function: "github.com/acme/db.Open.func1", want: "github.com/acme/db"},
+		{function: "gopkg.in/yaml%2ev3.Unmarshal", want: "gopkg.in/yaml.v3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			if got := functionPackage(tt.function); got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// callerPC returns the PC of its caller, like that of a record logged by the caller.
+func callerPC() uintptr {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	return pcs[0]
+}
+
+func testSource(filter slogic.Filter, pc uintptr) bool {
+	return filter(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "", pc))
+}
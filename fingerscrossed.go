@@ -0,0 +1,171 @@
+package slogic
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+var _ slog.Handler = (*FingersCrossedHandler)(nil)
+
+// NewFingersCrossedHandler constructs a [*FingersCrossedHandler] that wraps the given handler,
+// buffering up to size of the records that the passThrough filter filters out
+// until the trigger filter returns true for a record.
+//
+// For example, to only write DEBUG records when an ERROR follows them:
+//
+//	slogic.NewFingersCrossedHandler(handler,
+//		filter.IfLevelAtMost(slog.LevelDebug),
+//		filter.IfLevelAtLeast(slog.LevelError),
+//		100,
+//	)
+func NewFingersCrossedHandler(handler slog.Handler, passThrough, trigger Filter, size int) *FingersCrossedHandler {
+	return &FingersCrossedHandler{
+		handler:     handler,
+		passThrough: passThrough,
+		trigger:     trigger,
+		buffer:      &ring{size: size},
+	}
+}
+
+// A FingersCrossedHandler implements the [slog.Handler] interface.
+//
+// It passes the records that its pass-through filter does not filter out to the wrapped handler,
+// and holds the rest in a bounded buffer, discarding the oldest once it is full.
+// When its trigger filter returns true for a record, it passes every buffered record,
+// followed by the record itself, to the wrapped handler, emptying the buffer.
+//
+// The buffer is shared by the handler returned by [NewFingersCrossedHandler]
+// and every handler derived from it via WithAttrs and WithGroup,
+// so records are flushed along with those of the same scope,
+// e.g. a request, if a handler is constructed per scope.
+type FingersCrossedHandler struct {
+	handler     slog.Handler
+	passThrough Filter
+	trigger     Filter
+	buffer      *ring
+	scope
+}
+
+// A bufferedRecord is a record held by a handler beyond the Handle call that received it,
+// along with the context and handler with which to pass it on.
+type bufferedRecord struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// newBufferedRecord returns a bufferedRecord holding a clone of the given record.
+//
+// Its context is detached from the cancellation of the given context,
+// which is typically canceled once the call returns, e.g. at the end of an HTTP request,
+// whereas the record is passed on later.
+func newBufferedRecord(ctx context.Context, handler slog.Handler, r slog.Record) bufferedRecord {
+	return bufferedRecord{
+		ctx:     context.WithoutCancel(ctx),
+		handler: handler,
+		record:  r.Clone(),
+	}
+}
+
+// ring is a bounded FIFO of buffered records that overwrites the oldest once full.
+type ring struct {
+	mu      sync.Mutex
+	size    int
+	entries []bufferedRecord // grown as needed up to size, and reused thereafter
+	start   int
+	len     int
+}
+
+// push appends the given record, overwriting the oldest if the ring is full.
+func (b *ring) push(br bufferedRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case b.len < len(b.entries):
+		b.entries[(b.start+b.len)%len(b.entries)] = br
+		b.len++
+	case len(b.entries) < b.size:
+		// Until the entries have grown to size, none are overwritten, so start is 0...
+		b.entries = append(b.entries, br)
+		b.len++
+	case b.size > 0:
+		b.entries[b.start] = br
+		b.start = (b.start + 1) % len(b.entries)
+	}
+}
+
+// drain removes and returns every record, oldest first.
+func (b *ring) drain() []bufferedRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	brs := make([]bufferedRecord, b.len)
+	for i := range brs {
+		j := (b.start + i) % len(b.entries)
+		brs[i] = b.entries[j]
+		b.entries[j] = bufferedRecord{}
+	}
+	b.start, b.len = 0, 0
+	return brs
+}
+
+// Enabled implements the [slog.Handler] Enabled interface method.
+// It calls the wrapped handler's Enabled method.
+func (h *FingersCrossedHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements the [slog.Handler] Handle interface method.
+// It calls the wrapped handler's Handle method for the record, and any buffered records,
+// if the trigger filter returns true, or the pass-through filter returns false,
+// and otherwise buffers the record.
+//
+// When flushing, it returns the errors of all of the wrapped handler's Handle calls
+// joined via [errors.Join].
+func (h *FingersCrossedHandler) Handle(ctx context.Context, r slog.Record) error {
+	scoped := h.ctx(ctx)
+	if h.trigger(scoped, r) {
+		var errs []error
+		for _, br := range h.buffer.drain() {
+			if err := br.handler.Handle(br.ctx, br.record); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := h.handler.Handle(ctx, r); err != nil {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+	if !h.passThrough(scoped, r) {
+		return h.handler.Handle(ctx, r)
+	}
+	h.buffer.push(newBufferedRecord(ctx, h.handler, r))
+	return nil
+}
+
+// WithAttrs implements the [slog.Handler] WithAttrs interface method.
+// It calls the wrapped handler's WithAttrs method,
+// and retains the attributes so that they are visible to the filters via [Attrs].
+func (h *FingersCrossedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.handler = h.handler.WithAttrs(attrs)
+	h2.scope = h.with(groupOrAttrs{attrs: attrs})
+	return &h2
+}
+
+// WithGroup implements the [slog.Handler] WithGroup interface method.
+// It calls the wrapped handler's WithGroup method,
+// and retains the group so that it is visible to the filters via [Attrs].
+func (h *FingersCrossedHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.handler = h.handler.WithGroup(name)
+	h2.scope = h.with(groupOrAttrs{group: name})
+	return &h2
+}
@@ -0,0 +1,147 @@
+package slogic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+func TestFingersCrossedHandlerHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewFingersCrossedHandler(slog.NewJSONHandler(&buf, nil), mockFilter(false), mockFilter(false), 10)
+
+	err := slogtest.TestHandler(h, jsonResults(t, &buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFingersCrossedHandler(t *testing.T) {
+	atMostDebug := mockLevelFilter(func(l slog.Level) bool { return l <= slog.LevelDebug })
+	atLeastError := mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelError })
+
+	tests := []struct {
+		name string
+		size int
+		want string
+	}{
+		{
+			name: "buffered",
+			size: 10,
+			want: "level=INFO msg=info\n" +
+				"level=DEBUG msg=debug i=0\n" +
+				"level=DEBUG msg=debug i=1\n" +
+				"level=DEBUG msg=debug g.i=2\n" +
+				"level=ERROR msg=error\n" +
+				"level=ERROR msg=error\n",
+		},
+		{
+			name: "overflowed",
+			size: 2,
+			want: "level=INFO msg=info\n" +
+				"level=DEBUG msg=debug i=1\n" +
+				"level=DEBUG msg=debug g.i=2\n" +
+				"level=ERROR msg=error\n" +
+				"level=ERROR msg=error\n",
+		},
+		{
+			name: "unbuffered",
+			size: 0,
+			want: "level=INFO msg=info\n" +
+				"level=ERROR msg=error\n" +
+				"level=ERROR msg=error\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewFingersCrossedHandler(
+				slog.NewTextHandler(&buf, &slog.HandlerOptions{
+					Level: slog.LevelDebug,
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if len(groups) == 0 && a.Key == slog.TimeKey {
+							return slog.Attr{}
+						}
+						return a
+					},
+				}),
+				atMostDebug,
+				atLeastError,
+				tt.size,
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			logger := slog.New(h)
+			logger.DebugContext(ctx, "debug", "i", 0)
+			logger.InfoContext(ctx, "info")
+			logger.DebugContext(ctx, "debug", "i", 1)
+			logger.WithGroup("g").DebugContext(ctx, "debug", "i", 2)
+			cancel()
+			logger.Error("error")
+			// The buffer was emptied...
+			logger.Error("error")
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFingersCrossedHandlerErrors(t *testing.T) {
+	errA := errors.New("a")
+	h := NewFingersCrossedHandler(errorHandler{errA}, mockFilter(true), mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelError }), 10)
+
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelDebug, "debug", 0)); err != nil {
+		t.Errorf("got: %v, want: <nil>", err)
+	}
+	err := h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelError, "error", 0))
+	if got, want := len(err.(interface{ Unwrap() []error }).Unwrap()), 2; !errors.Is(err, errA) || got != want {
+		t.Errorf("got: %v, want: a twice", err)
+	}
+}
+
+func TestRing(t *testing.T) {
+	b := &ring{size: 3}
+	push := func(msgs ...string) {
+		for _, msg := range msgs {
+			b.push(bufferedRecord{record: slog.NewRecord(time.Time{}, slog.LevelInfo, msg, 0)})
+		}
+	}
+	drain := func() []string {
+		var msgs []string
+		for _, br := range b.drain() {
+			msgs = append(msgs, br.record.Message)
+		}
+		return msgs
+	}
+
+	push("a", "b")
+	if got := len(b.entries); got != 2 {
+		t.Errorf("got: %d entries, want: 2", got)
+	}
+	if got, want := drain(), []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	// Reuses the existing entries before growing, then overwrites the oldest...
+	push("c", "d", "e", "f", "g")
+	if got := len(b.entries); got != 3 {
+		t.Errorf("got: %d entries, want: 3", got)
+	}
+	if got, want := drain(), []string{"e", "f", "g"}; !slices.Equal(got, want) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+
+	b = &ring{size: 0}
+	push("a")
+	if got := drain(); len(got) != 0 {
+		t.Errorf("got: %q, want: none", got)
+	}
+}
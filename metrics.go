@@ -0,0 +1,143 @@
+package slogic
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+var _ expvar.Var = (*Metrics)(nil)
+
+// Metrics counts the evaluations of named filters, broken down by level.
+//
+// A Metrics implements the [expvar.Var] interface,
+// so it can be exported via e.g. [expvar.Publish].
+type Metrics struct {
+	mu      sync.RWMutex
+	filters map[string]*counters
+}
+
+// NewMetrics constructs an empty [*Metrics].
+func NewMetrics() *Metrics {
+	return &Metrics{filters: make(map[string]*counters)}
+}
+
+// Filter returns a [Filter] that behaves like the given filter,
+// counting its evaluations and true results under the given name.
+//
+// The returned filter has the same description as the given filter, per [Inspect],
+// so it can wrap any node of a filter tree without affecting e.g. [Handler.Enabled].
+// Wrapping the root filter of a [Handler] counts the records the handler drops;
+// records whose levels are disabled by the handler are not evaluated, and therefore not counted.
+//
+// Filters given the same name share their counts.
+func (m *Metrics) Filter(name string, filter Filter) Filter {
+	c := m.counters(name)
+	counted := func(ctx context.Context, r slog.Record) bool {
+		result := filter(ctx, r)
+		c.add(r.Level, result)
+		return result
+	}
+	if d := describedOf(filter); d != nil {
+		return describeOp(d.op, counted, d.desc)
+	}
+	return counted
+}
+
+func (m *Metrics) counters(name string) *counters {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.filters[name]
+	if !ok {
+		c = &counters{levels: make(map[slog.Level]*levelCounters)}
+		m.filters[name] = c
+	}
+	return c
+}
+
+// Snapshot returns the current counts of each named filter.
+func (m *Metrics) Snapshot() map[string]FilterStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snapshot := make(map[string]FilterStats, len(m.filters))
+	for name, c := range m.filters {
+		snapshot[name] = c.stats()
+	}
+	return snapshot
+}
+
+// String implements the [expvar.Var] String interface method,
+// returning the JSON encoding of the metrics' [Metrics.Snapshot].
+func (m *Metrics) String() string {
+	data, _ := json.Marshal(m.Snapshot())
+	return string(data)
+}
+
+// FilterStats holds the counts of a named filter's evaluations.
+type FilterStats struct {
+	// Evaluations is the number of records the filter evaluated.
+	Evaluations uint64 `json:"evaluations"`
+
+	// Matches is the number of records for which the filter returned true.
+	Matches uint64 `json:"matches"`
+
+	// Levels breaks down the counts by the records' levels.
+	Levels map[slog.Level]LevelStats `json:"levels"`
+}
+
+// LevelStats holds the counts of a named filter's evaluations of records with a given level.
+type LevelStats struct {
+	// Evaluations is the number of records with the level the filter evaluated.
+	Evaluations uint64 `json:"evaluations"`
+
+	// Matches is the number of records with the level for which the filter returned true.
+	Matches uint64 `json:"matches"`
+}
+
+type counters struct {
+	mu     sync.RWMutex
+	levels map[slog.Level]*levelCounters
+}
+
+type levelCounters struct {
+	evaluations atomic.Uint64
+	matches     atomic.Uint64
+}
+
+func (c *counters) add(level slog.Level, result bool) {
+	c.mu.RLock()
+	lc, ok := c.levels[level]
+	c.mu.RUnlock()
+	if !ok {
+		c.mu.Lock()
+		if lc, ok = c.levels[level]; !ok {
+			lc = new(levelCounters)
+			c.levels[level] = lc
+		}
+		c.mu.Unlock()
+	}
+
+	lc.evaluations.Add(1)
+	if result {
+		lc.matches.Add(1)
+	}
+}
+
+func (c *counters) stats() FilterStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := FilterStats{Levels: make(map[slog.Level]LevelStats, len(c.levels))}
+	for level, lc := range c.levels {
+		ls := LevelStats{
+			Evaluations: lc.evaluations.Load(),
+			Matches:     lc.matches.Load(),
+		}
+		stats.Evaluations += ls.Evaluations
+		stats.Matches += ls.Matches
+		stats.Levels[level] = ls
+	}
+	return stats
+}
@@ -0,0 +1,87 @@
+package slogic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics()
+	atMostInfo := mockLevelFilter(func(l slog.Level) bool { return l <= slog.LevelInfo })
+	h := NewHandler(
+		slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		m.Filter("root", Or(
+			m.Filter("info", atMostInfo),
+			m.Filter("custom", mockFilter(false)),
+		)),
+	)
+
+	logger := slog.New(h)
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Error("error")
+
+	want := map[string]FilterStats{
+		"root": {
+			Evaluations: 3,
+			Matches:     0,
+			Levels: map[slog.Level]LevelStats{
+				slog.LevelWarn:  {Evaluations: 1},
+				slog.LevelError: {Evaluations: 2},
+			},
+		},
+		"info": {
+			Evaluations: 3,
+			Levels: map[slog.Level]LevelStats{
+				slog.LevelWarn:  {Evaluations: 1},
+				slog.LevelError: {Evaluations: 2},
+			},
+		},
+		"custom": {
+			Evaluations: 3,
+			Levels: map[slog.Level]LevelStats{
+				slog.LevelWarn:  {Evaluations: 1},
+				slog.LevelError: {Evaluations: 2},
+			},
+		},
+	}
+	got := m.Snapshot()
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("got: %s, want: %s", gotJSON, wantJSON)
+	}
+	if m.String() != string(wantJSON) {
+		t.Errorf("got: %s, want: %s", m.String(), wantJSON)
+	}
+
+	// The level-only filters are still known to drop DEBUG and INFO...
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("got: enabled, want: disabled")
+	}
+	if got, want := h.Filter().String(), "Or(mockLevelFilter(), Filter)"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestMetricsMatches(t *testing.T) {
+	m := NewMetrics()
+	f := m.Filter("f", func(_ context.Context, r slog.Record) bool {
+		return r.Message == "drop"
+	})
+
+	for _, msg := range []string{"drop", "keep", "drop"} {
+		f(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, msg, 0))
+	}
+
+	got := m.Snapshot()["f"]
+	if got.Evaluations != 3 || got.Matches != 2 || got.Levels[slog.LevelInfo].Matches != 2 {
+		t.Errorf("got: %+v, want: 3 evaluations, 2 matches", got)
+	}
+}
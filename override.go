@@ -0,0 +1,130 @@
+package slogic
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithOverride returns a copy of the given context that carries the given filter,
+// such that a [Handler] uses it in place of its own filter for records logged with the context,
+// e.g. to log a single request at a more verbose level.
+//
+// A nil filter removes any override carried by the given context.
+func WithOverride(ctx context.Context, filter Filter) context.Context {
+	var state *filterState
+	if filter != nil {
+		state = &filterState{
+			filter: filter,
+			drops:  levelResult(filter),
+		}
+	}
+	return context.WithValue(ctx, overrideKey{}, state)
+}
+
+// Override returns the filter carried by the given context per [WithOverride], if any.
+func Override(ctx context.Context) (Filter, bool) {
+	state, _ := ctx.Value(overrideKey{}).(*filterState)
+	if state == nil {
+		return nil, false
+	}
+	return state.filter, true
+}
+
+type overrideKey struct{}
+
+// WithMinimumLevel returns a copy of the given context that carries the given level,
+// such that a [Handler] keeps the records logged with the context that have at least the given level,
+// unless its filter, or override per [WithOverride], would filter them out regardless of their level,
+// e.g. to additionally log a single request's DEBUG records.
+//
+// Specifically, the parts of the filter whose results depend on the record's level alone,
+// per [Description.Level], such as [go.luke.ph/slogic/filter.IfLevelAtMost],
+// or on its level relative to a minimum level, per [Description.MinimumLevel], are relaxed
+// so as to keep such records, whereas its other parts, such as those filtering out
+// health checks or sensitive attributes, still apply. As such, a level at or above
+// the filter's existing minimum level has no effect.
+func WithMinimumLevel(ctx context.Context, level slog.Level) context.Context {
+	return context.WithValue(ctx, minimumLevelKey{}, level)
+}
+
+// MinimumLevel returns the level carried by the given context per [WithMinimumLevel], if any.
+func MinimumLevel(ctx context.Context) (slog.Level, bool) {
+	level, ok := ctx.Value(minimumLevelKey{}).(slog.Level)
+	return level, ok
+}
+
+type minimumLevelKey struct{}
+
+// state returns the filter state to use for records logged with the given context:
+// that of its override, if any, or else the handler's own, relaxed per its minimum level, if any.
+func (h *Handler) state(ctx context.Context) *filterState {
+	state, _ := ctx.Value(overrideKey{}).(*filterState)
+	if state == nil {
+		state = h.filter.Load()
+	}
+	if level, ok := MinimumLevel(ctx); ok {
+		return state.relaxed(level)
+	}
+	return state
+}
+
+// relaxed returns the state of the filter relaxed to the given minimum level per [WithMinimumLevel],
+// constructing it only once per level.
+func (s *filterState) relaxed(level slog.Level) *filterState {
+	if r, ok := s.relaxedByLevel.Load(level); ok {
+		return r.(*filterState)
+	}
+	filter := relaxLevel(s.filter, level, false)
+	r, _ := s.relaxedByLevel.LoadOrStore(level, &filterState{
+		filter: filter,
+		drops:  levelResult(filter),
+	})
+	return r.(*filterState)
+}
+
+// relaxLevel returns a filter like the given one, but whose level-only parts return false
+// for records with at least the given level, or true if negated per [Not],
+// so that the filter as a whole returns false for such records unless its other parts return true.
+func relaxLevel(filter Filter, level slog.Level, negated bool) Filter {
+	d := describedOf(filter)
+	if d == nil {
+		return filter
+	}
+	if result := d.desc.Level; result != nil {
+		below := func(l slog.Level) bool { return l < level }
+		relaxed := func(l slog.Level) bool { return below(l) && result(l) }
+		if negated {
+			relaxed = func(l slog.Level) bool { return !below(l) || result(l) }
+		}
+		return Describe(func(_ context.Context, r slog.Record) bool {
+			return relaxed(r.Level)
+		}, Description{Name: "MinimumLevel", Args: []any{level}, Filters: []Filter{filter}, Level: relaxed})
+	}
+	if minimum := d.desc.MinimumLevel; minimum != nil {
+		desc := Description{Name: "MinimumLevel", Args: []any{level}, Filters: []Filter{filter}}
+		if negated {
+			return Describe(func(ctx context.Context, r slog.Record) bool {
+				return r.Level >= level || filter(ctx, r)
+			}, desc)
+		}
+		desc.MinimumLevel = func(r slog.Record) slog.Level { return min(minimum(r), level) }
+		desc.LowestLevel = min(d.desc.LowestLevel, level)
+		return Describe(func(_ context.Context, r slog.Record) bool {
+			return r.Level < desc.MinimumLevel(r)
+		}, desc)
+	}
+
+	filters := make([]Filter, len(d.desc.Filters))
+	for i, f := range d.desc.Filters {
+		filters[i] = relaxLevel(f, level, negated != (d.op == opNot))
+	}
+	switch d.op {
+	case opAnd:
+		return And(filters...)
+	case opOr:
+		return Or(filters...)
+	case opNot:
+		return Not(filters[0])
+	}
+	return filter
+}
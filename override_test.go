@@ -0,0 +1,136 @@
+package slogic
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWithOverride(t *testing.T) {
+	atMostInfo := mockLevelFilter(func(l slog.Level) bool { return l <= slog.LevelInfo })
+	atMostDebug := mockLevelFilter(func(l slog.Level) bool { return l < slog.LevelInfo })
+
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}), atMostInfo)
+	logger := slog.New(h).With("a", 1)
+
+	override := WithOverride(context.Background(), atMostDebug)
+	removed := WithOverride(override, nil)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{name: "none", ctx: context.Background(), want: false},
+		{name: "override", ctx: override, want: true},
+		{name: "removed", ctx: removed, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			if got := logger.Enabled(tt.ctx, slog.LevelInfo); got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+			// Handle is called regardless of Enabled...
+			if err := logger.Handler().Handle(tt.ctx, slog.NewRecord(time.Time{}, slog.LevelInfo, "info", 0)); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.Len() > 0; got != tt.want {
+				t.Errorf("got: %q, want: %v", buf.String(), tt.want)
+			}
+			if _, got := Override(tt.ctx); got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMinimumLevel(t *testing.T) {
+	atMostInfo := mockLevelFilter(func(l slog.Level) bool { return l <= slog.LevelInfo })
+	atLeastWarn := mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelWarn })
+	health := func(_ context.Context, r slog.Record) bool { return r.Message == "health" }
+
+	tests := []struct {
+		name   string
+		filter Filter
+		level  slog.Level
+		record slog.Record
+		want   bool
+	}{
+		{
+			name:   "relaxed",
+			filter: atMostInfo,
+			level:  slog.LevelDebug,
+			record: slog.NewRecord(time.Time{}, slog.LevelDebug, "debug", 0),
+			want:   false,
+		},
+		{
+			name:   "below minimum level",
+			filter: atMostInfo,
+			level:  slog.LevelInfo,
+			record: slog.NewRecord(time.Time{}, slog.LevelDebug, "debug", 0),
+			want:   true,
+		},
+		{
+			name:   "other filters apply",
+			filter: Or(atMostInfo, health),
+			level:  slog.LevelDebug,
+			record: slog.NewRecord(time.Time{}, slog.LevelDebug, "health", 0),
+			want:   true,
+		},
+		{
+			name:   "negated",
+			filter: Not(Or(atLeastWarn, health)),
+			level:  slog.LevelDebug,
+			record: slog.NewRecord(time.Time{}, slog.LevelInfo, "info", 0),
+			want:   false,
+		},
+		{
+			name:   "above minimum level",
+			filter: atMostInfo,
+			level:  slog.LevelError,
+			record: slog.NewRecord(time.Time{}, slog.LevelWarn, "warn", 0),
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}), tt.filter)
+			ctx := WithMinimumLevel(context.Background(), tt.level)
+
+			got := h.state(ctx).filter(ctx, tt.record)
+			if got != tt.want {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+			if level, ok := MinimumLevel(ctx); !ok || level != tt.level {
+				t.Errorf("got: %v, %v, want: %v, true", level, ok, tt.level)
+			}
+		})
+	}
+
+	// Levels are reported as enabled per the relaxed filter...
+	h := NewHandler(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}), atMostInfo)
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("got: true, want: false")
+	}
+	if !h.Enabled(WithMinimumLevel(context.Background(), slog.LevelDebug), slog.LevelDebug) {
+		t.Error("got: false, want: true")
+	}
+	if _, ok := MinimumLevel(context.Background()); ok {
+		t.Error("got: true, want: false")
+	}
+}
@@ -0,0 +1,138 @@
+package slogic
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+var _ slog.Handler = (*Router)(nil)
+
+// A Route pairs a [Filter] with the [slog.Handler] that is passed the records it does not filter out.
+// A route with a nil Filter accepts every record.
+type Route struct {
+	Filter  Filter
+	Handler slog.Handler
+}
+
+// A RouteMode determines which of a [Router]'s routes are passed each record.
+type RouteMode int
+
+const (
+	// FirstMatch passes each record to the first route whose filter does not filter it out.
+	FirstMatch RouteMode = iota
+
+	// AllMatches passes each record to every route whose filter does not filter it out.
+	AllMatches
+)
+
+// NewRouter constructs a [*Router] that fans records out to the given routes,
+// in order, per the given mode.
+func NewRouter(mode RouteMode, routes ...Route) *Router {
+	rs := make([]route, len(routes))
+	for i, r := range routes {
+		rs[i] = route{Route: r}
+		if r.Filter != nil {
+			rs[i].drops = levelResult(r.Filter)
+		}
+	}
+	return &Router{mode: mode, routes: rs}
+}
+
+// A Router implements the [slog.Handler] interface,
+// passing each record to one or more of its routes' handlers.
+type Router struct {
+	mode   RouteMode
+	routes []route
+	scope
+}
+
+type route struct {
+	Route
+	drops func(slog.Level) tri
+}
+
+// result returns whether the route's filter is known to filter out records with the given level.
+func (r route) result(level slog.Level) tri {
+	if r.Filter == nil {
+		return isFalse
+	}
+	return resultAt(r.drops, level)
+}
+
+// Enabled implements the [slog.Handler] Enabled interface method.
+// It returns true if any route that may be passed a record with the given level
+// has a handler that is enabled for the level.
+func (h *Router) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, r := range h.routes {
+		result := r.result(level)
+		if result != isTrue && r.Handler.Enabled(ctx, level) {
+			return true
+		}
+		if result == isFalse && h.mode == FirstMatch {
+			// No subsequent route can be passed the record...
+			return false
+		}
+	}
+	return false
+}
+
+// Handle implements the [slog.Handler] Handle interface method.
+// It calls the Handle method of the handler of each route per the router's [RouteMode],
+// provided the handler is enabled for the record's level,
+// returning the errors of all such calls joined via [errors.Join].
+func (h *Router) Handle(ctx context.Context, r slog.Record) error {
+	scoped := h.ctx(ctx)
+	var errs []error
+	for _, route := range h.routes {
+		if route.Filter != nil && route.Filter(scoped, r) {
+			continue
+		}
+		if route.Handler.Enabled(ctx, r.Level) {
+			if err := route.Handler.Handle(ctx, r.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if h.mode == FirstMatch {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs implements the [slog.Handler] WithAttrs interface method.
+// It calls the WithAttrs method of every route's handler,
+// and retains the attributes so that they are visible to the routes' filters via [Attrs].
+func (h *Router) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.derive(groupOrAttrs{attrs: attrs}, func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+// WithGroup implements the [slog.Handler] WithGroup interface method.
+// It calls the WithGroup method of every route's handler,
+// and retains the group so that it is visible to the routes' filters via [Attrs].
+func (h *Router) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.derive(groupOrAttrs{group: name}, func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+func (h *Router) derive(goa groupOrAttrs, with func(slog.Handler) slog.Handler) *Router {
+	routes := make([]route, len(h.routes))
+	for i, r := range h.routes {
+		r.Handler = with(r.Handler)
+		routes[i] = r
+	}
+	return &Router{
+		mode:   h.mode,
+		routes: routes,
+		scope:  h.with(goa),
+	}
+}
@@ -0,0 +1,167 @@
+package slogic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"testing/slogtest"
+)
+
+func TestRouterHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewRouter(
+		FirstMatch,
+		Route{Filter: mockFilter(true), Handler: slog.NewTextHandler(io.Discard, nil)},
+		Route{Handler: slog.NewJSONHandler(&buf, nil)},
+	)
+
+	err := slogtest.TestHandler(h, jsonResults(t, &buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRouter(t *testing.T) {
+	isError := mockLevelFilter(func(l slog.Level) bool { return l == slog.LevelError })
+	isTenant := func(ctx context.Context, r slog.Record) bool {
+		for groups, attr := range Attrs(ctx, r) {
+			if len(groups) == 0 && attr.Key == "tenant" {
+				return true
+			}
+		}
+		return false
+	}
+
+	tests := []struct {
+		name string
+		mode RouteMode
+		want [3]string
+	}{
+		{
+			name: "first match",
+			mode: FirstMatch,
+			want: [3]string{
+				"msg=info tenant=acme g.k=v\n",
+				"msg=error\n",
+				"",
+			},
+		},
+		{
+			name: "all matches",
+			mode: AllMatches,
+			want: [3]string{
+				"msg=info tenant=acme g.k=v\n",
+				"msg=error\nmsg=info tenant=acme g.k=v\n",
+				"msg=error\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bufs [3]bytes.Buffer
+			handler := func(buf *bytes.Buffer) slog.Handler {
+				return slog.NewTextHandler(buf, &slog.HandlerOptions{
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+							return slog.Attr{}
+						}
+						return a
+					},
+				})
+			}
+			h := NewRouter(
+				tt.mode,
+				Route{Filter: Not(isTenant), Handler: handler(&bufs[0])},
+				Route{Handler: handler(&bufs[1])},
+				Route{Filter: Not(isError), Handler: handler(&bufs[2])},
+			)
+
+			slog.New(h).Error("error")
+			slog.New(h).With("tenant", "acme").WithGroup("g").Info("info", "k", "v")
+
+			for i := range bufs {
+				if got := bufs[i].String(); got != tt.want[i] {
+					t.Errorf("route %d got: %q, want: %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRouterEnabled(t *testing.T) {
+	atLeastWarn := mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelWarn })
+	handler := func(level slog.Level) slog.Handler {
+		return slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: level})
+	}
+
+	tests := []struct {
+		name   string
+		router *Router
+		want   []slog.Level
+	}{
+		{
+			name: "any enabled",
+			router: NewRouter(
+				AllMatches,
+				Route{Handler: handler(slog.LevelError)},
+				Route{Filter: atLeastWarn, Handler: handler(slog.LevelDebug)},
+			),
+			want: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelError},
+		},
+		{
+			name: "first match",
+			router: NewRouter(
+				FirstMatch,
+				Route{Filter: Not(atLeastWarn), Handler: handler(slog.LevelError)},
+				Route{Handler: handler(slog.LevelDebug)},
+			),
+			want: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelError},
+		},
+		{
+			name:   "empty",
+			router: NewRouter(FirstMatch),
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []slog.Level
+			for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+				if tt.router.Enabled(context.Background(), level) {
+					got = append(got, level)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouterErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	h := NewRouter(
+		AllMatches,
+		Route{Handler: errorHandler{errA}},
+		Route{Handler: errorHandler{nil}},
+		Route{Handler: errorHandler{errB}},
+	)
+
+	err := h.Handle(context.Background(), slog.Record{})
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("got: %v, want: a and b", err)
+	}
+}
+
+type errorHandler struct{ err error }
+
+func (h errorHandler) Enabled(context.Context, slog.Level) bool  { return true }
+func (h errorHandler) Handle(context.Context, slog.Record) error { return h.err }
+func (h errorHandler) WithAttrs([]slog.Attr) slog.Handler        { return h }
+func (h errorHandler) WithGroup(string) slog.Handler             { return h }
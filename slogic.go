@@ -5,91 +5,265 @@ package slogic // import "go.luke.ph/slogic"
 
 import (
 	"context"
+	"iter"
 	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
 )
 
 var _ slog.Handler = (*Handler)(nil)
 
 // A Filter returns true if the given [slog.Record] should be filtered out,
 // and returns false if not.
+//
+// Filters invoked by a [Handler] can use [Attrs] to inspect the attributes
+// added to the handler via WithAttrs alongside those of the record itself.
 type Filter func(context.Context, slog.Record) bool
 
 // NewHandler constructs a [*Handler] that wraps the given handler with a filter.
 func NewHandler(handler slog.Handler, filter Filter) *Handler {
-	return &Handler{
+	return NewHandlerWithOptions(handler, filter, nil)
+}
+
+// NewHandlerWithOptions constructs a [*Handler] that wraps the given handler with a filter,
+// using the given options. A nil opts is equivalent to the zero [HandlerOptions].
+func NewHandlerWithOptions(handler slog.Handler, filter Filter, opts *HandlerOptions) *Handler {
+	h := &Handler{
 		handler: handler,
-		filter:  filter,
+		filter:  new(atomic.Pointer[filterState]),
+	}
+	if opts != nil {
+		h.opts = *opts
+		h.dropped = opts.Dropped
 	}
+	h.SetFilter(filter)
+	return h
+}
+
+// HandlerOptions are options for a [Handler].
+type HandlerOptions struct {
+	// Explain, if true, puts the handler in a debug mode in which no records are filtered out.
+	// Instead, each record is passed to the wrapped handler along with an attribute
+	// with the key [ExplainKey], holding the [Explanation] of the filter's result.
+	Explain bool
+
+	// Dropped, if non-nil, is passed the records that the filter filters out,
+	// rather than them being discarded. Attributes and groups added to the handler
+	// via WithAttrs and WithGroup are added to Dropped too.
+	// Dropped is unused if Explain is true.
+	Dropped slog.Handler
 }
 
 // A Handler implements the [slog.Handler] interface.
 type Handler struct {
 	handler slog.Handler
-	filter  Filter
+	dropped slog.Handler
+	filter  *atomic.Pointer[filterState]
+	opts    HandlerOptions
+	scope
 }
 
+// filterState holds a [Handler]'s filter,
+// along with the levels it is known to drop.
+type filterState struct {
+	filter Filter
+	drops  func(slog.Level) tri
+
+	relaxedByLevel sync.Map // of slog.Level to *filterState, per [WithMinimumLevel]
+}
+
+// Filter returns the handler's current filter.
+func (h *Handler) Filter() Filter {
+	return h.filter.Load().filter
+}
+
+// SetFilter atomically replaces the handler's filter.
+//
+// The filter is shared by the handler returned by [NewHandler]
+// and every handler derived from it via WithAttrs and WithGroup,
+// so the new filter takes effect for all of them immediately.
+// SetFilter is safe to call concurrently with Handle.
+func (h *Handler) SetFilter(filter Filter) {
+	h.filter.Store(&filterState{
+		filter: filter,
+		drops:  levelResult(filter),
+	})
+}
+
+// scope holds the groups and attributes added to a handler via WithGroup and WithAttrs,
+// which are visible via [Attrs] to the filters that it invokes.
+type scope struct {
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs holds either a group name or a list of attributes
+// added to a handler via WithGroup or WithAttrs.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// with returns a copy of the scope with goa appended,
+// leaving the original unmodified so that it can be shared among handlers.
+func (s scope) with(goa groupOrAttrs) scope {
+	return scope{goas: append(slices.Clip(s.goas), goa)}
+}
+
+// ctx returns a context that carries the scope for [Attrs],
+// or the given context as-is if the scope is empty.
+func (s scope) ctx(ctx context.Context) context.Context {
+	if len(s.goas) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, scopeKey{}, s.goas)
+}
+
+type scopeKey struct{}
+
 // Enabled implements the [slog.Handler] Enabled interface method.
-// It calls the wrapped handler's Enabled method, unaffected by the filter.
+// It returns false if the filter is known to return true for every record with the given level,
+// as is the case for e.g. [go.luke.ph/slogic/filter.IfLevelAtMost] combined via [And], [Or] and [Not],
+// and otherwise calls the wrapped handler's Enabled method.
+//
+// If [HandlerOptions.Dropped] is set, it also returns true if the Dropped handler is enabled
+// for a level whose records the filter may filter out.
+//
+// If the context carries a filter per [WithOverride], it is used in place of the handler's filter.
 func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.handler.Enabled(ctx, level)
+	result := unknown
+	if drops := h.state(ctx).drops; drops != nil && !h.opts.Explain {
+		result = drops(level)
+	}
+	if result != isTrue && h.handler.Enabled(ctx, level) {
+		return true
+	}
+	return h.dropped != nil && result != isFalse && h.dropped.Enabled(ctx, level)
 }
 
 // Handle implements the [slog.Handler] Handle interface method.
-// It calls the wrapped handler's Handle method only if the filter returns false.
+// It calls the wrapped handler's Handle method only if the filter returns false,
+// and otherwise calls the [HandlerOptions.Dropped] handler's Handle method, if set.
+//
+// If the context carries a filter per [WithOverride], it is used in place of the handler's filter.
 func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
-	if h.filter(ctx, r) {
+	filter := h.state(ctx).filter
+	if h.opts.Explain {
+		e := Explain(h.ctx(ctx), filter, r)
+		r = r.Clone()
+		r.AddAttrs(slog.String(ExplainKey, e.String()))
+		return h.handler.Handle(ctx, r)
+	}
+
+	handler := h.handler
+	if filter(h.ctx(ctx), r) {
+		handler = h.dropped
+	}
+	if handler == nil {
+		return nil
+	}
+	// With a Dropped handler, Enabled may have returned true on behalf of only one of the handlers...
+	if h.dropped != nil && !handler.Enabled(ctx, r.Level) {
 		return nil
 	}
-	return h.handler.Handle(ctx, r)
+	return handler.Handle(ctx, r)
 }
 
 // WithAttrs implements the [slog.Handler] WithAttrs interface method.
-// It calls the wrapped handler's WithAttrs method, unaffected by the filter.
+// It calls the wrapped handler's WithAttrs method,
+// and retains the attributes so that they are visible to the filter via [Attrs].
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &Handler{
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := &Handler{
 		handler: h.handler.WithAttrs(attrs),
 		filter:  h.filter,
+		opts:    h.opts,
+		scope:   h.with(groupOrAttrs{attrs: attrs}),
+	}
+	if h.dropped != nil {
+		h2.dropped = h.dropped.WithAttrs(attrs)
 	}
+	return h2
 }
 
 // WithGroup implements the [slog.Handler] WithGroup interface method.
-// It calls the wrapped handler's WithGroup method, unaffected by the filter.
+// It calls the wrapped handler's WithGroup method,
+// and retains the group so that it is visible to the filter via [Attrs].
 func (h *Handler) WithGroup(name string) slog.Handler {
-	return &Handler{
+	if name == "" {
+		return h
+	}
+	h2 := &Handler{
 		handler: h.handler.WithGroup(name),
 		filter:  h.filter,
+		opts:    h.opts,
+		scope:   h.with(groupOrAttrs{group: name}),
+	}
+	if h.dropped != nil {
+		h2.dropped = h.dropped.WithGroup(name)
+	}
+	return h2
+}
+
+// Attrs returns an iterator over the attributes visible to a [Filter]
+// evaluating the given record: first those added to the [Handler] via WithAttrs,
+// then those of the record itself.
+//
+// Each attribute is yielded along with the names of the groups,
+// added to the [Handler] via WithGroup, that it is nested within.
+// The groups slice is reused between iterations and must not be retained.
+func Attrs(ctx context.Context, r slog.Record) iter.Seq2[[]string, slog.Attr] {
+	return func(yield func([]string, slog.Attr) bool) {
+		goas, _ := ctx.Value(scopeKey{}).([]groupOrAttrs)
+		var groups []string
+		for _, goa := range goas {
+			if goa.group != "" {
+				groups = append(groups, goa.group)
+				continue
+			}
+			for _, attr := range goa.attrs {
+				if !yield(groups, attr) {
+					return
+				}
+			}
+		}
+		r.Attrs(func(attr slog.Attr) bool {
+			return yield(groups, attr)
+		})
 	}
 }
 
 // And combines multiple filters into a single [Filter]
 // that returns true if ALL of the given filters return true.
 func And(filters ...Filter) Filter {
-	return func(ctx context.Context, r slog.Record) bool {
+	return describeOp(opAnd, func(ctx context.Context, r slog.Record) bool {
 		for _, filter := range filters {
 			if !filter(ctx, r) {
 				return false
 			}
 		}
 		return true
-	}
+	}, Description{Name: "And", Filters: filters})
 }
 
 // Or combines multiple filters into a single [Filter]
 // that returns true if ANY of the given filters return true.
 func Or(filters ...Filter) Filter {
-	return func(ctx context.Context, r slog.Record) bool {
+	return describeOp(opOr, func(ctx context.Context, r slog.Record) bool {
 		for _, filter := range filters {
 			if filter(ctx, r) {
 				return true
 			}
 		}
 		return false
-	}
+	}, Description{Name: "Or", Filters: filters})
 }
 
 // Not returns a [Filter] that negates the result of the given filter.
 func Not(filter Filter) Filter {
-	return func(ctx context.Context, r slog.Record) bool {
+	return describeOp(opNot, func(ctx context.Context, r slog.Record) bool {
 		return !filter(ctx, r)
-	}
+	}, Description{Name: "Not", Filters: []Filter{filter}})
 }
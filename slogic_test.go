@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"testing/slogtest"
 )
@@ -16,24 +20,274 @@ func TestHandler(t *testing.T) {
 		mockFilter(false),
 	)
 
-	results := func() []map[string]any {
-		var ms []map[string]any
-		for line := range bytes.SplitSeq(buf.Bytes(), []byte{'\n'}) {
-			if len(line) == 0 {
-				continue
+	err := slogtest.TestHandler(h, jsonResults(t, &buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandlerEnabled(t *testing.T) {
+	atMostInfo := mockLevelFilter(func(l slog.Level) bool { return l <= slog.LevelInfo })
+	isError := mockLevelFilter(func(l slog.Level) bool { return l == slog.LevelError })
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []slog.Level
+	}{
+		{
+			name:   "level",
+			filter: atMostInfo,
+			want:   []slog.Level{slog.LevelWarn, slog.LevelError},
+		},
+		{
+			name:   "not level",
+			filter: Not(atMostInfo),
+			want:   []slog.Level{slog.LevelDebug, slog.LevelInfo},
+		},
+		{
+			name:   "or",
+			filter: Or(atMostInfo, isError),
+			want:   []slog.Level{slog.LevelWarn},
+		},
+		{
+			name:   "and unknown",
+			filter: And(atMostInfo, mockFilter(true)),
+			want:   []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError},
+		},
+		{
+			name:   "or unknown",
+			filter: Or(atMostInfo, mockFilter(false)),
+			want:   []slog.Level{slog.LevelWarn, slog.LevelError},
+		},
+		{
+			name:   "and not or",
+			filter: And(Not(Or(isError, And(Not(atMostInfo), mockFilter(true)))), atMostInfo),
+			want:   []slog.Level{slog.LevelWarn, slog.LevelError},
+		},
+		{
+			name:   "unknown",
+			filter: mockFilter(true),
+			want:   []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError},
+		},
+		{
+			name: "wrapped",
+			filter: func(ctx context.Context, r slog.Record) bool {
+				return !atMostInfo(ctx, r)
+			},
+			want: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}), tt.filter)
+			var got []slog.Level
+			for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+				if h.WithGroup("g").Enabled(context.Background(), level) {
+					got = append(got, level)
+				}
 			}
-			var m map[string]any
-			if err := json.Unmarshal(line, &m); err != nil {
-				t.Fatal(err)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", got, tt.want)
 			}
-			ms = append(ms, m)
+		})
+	}
+}
+
+func TestHandlerDropped(t *testing.T) {
+	var kept, dropped bytes.Buffer
+	h := NewHandlerWithOptions(
+		slog.NewTextHandler(&kept, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		Or(
+			mockLevelFilter(func(l slog.Level) bool { return l < slog.LevelInfo }),
+			func(_ context.Context, r slog.Record) bool { return r.Message == "drop" },
+		),
+		&HandlerOptions{Dropped: slog.NewTextHandler(&dropped, &slog.HandlerOptions{Level: slog.LevelDebug})},
+	)
+
+	logger := slog.New(h).With("a", 1).WithGroup("g")
+	logger.Debug("debug", "b", 2)
+	logger.Info("drop", "b", 2)
+	logger.Info("keep", "b", 2)
+
+	if got, want := kept.String(), "level=INFO msg=keep a=1 g.b=2\n"; !strings.HasSuffix(got, want) || strings.Count(got, "\n") != 1 {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got := dropped.String(); !strings.Contains(got, "level=DEBUG msg=debug a=1 g.b=2\n") || !strings.Contains(got, "level=INFO msg=drop a=1 g.b=2\n") || strings.Count(got, "\n") != 2 {
+		t.Errorf("got: %q, want: debug and drop records", got)
+	}
+}
+
+func TestHandlerDroppedEnabled(t *testing.T) {
+	debugOnly := mockLevelFilter(func(l slog.Level) bool { return l == slog.LevelDebug })
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		kept    slog.Level
+		dropped slog.Level
+		want    []slog.Level
+	}{
+		{
+			name:    "dropped more verbose",
+			filter:  debugOnly,
+			kept:    slog.LevelInfo,
+			dropped: slog.LevelDebug,
+			want:    []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError},
+		},
+		{
+			name:    "kept levels only",
+			filter:  Not(debugOnly),
+			kept:    slog.LevelDebug,
+			dropped: slog.LevelError,
+			want:    []slog.Level{slog.LevelDebug, slog.LevelError},
+		},
+		{
+			name:    "unknown",
+			filter:  mockFilter(true),
+			kept:    slog.LevelError,
+			dropped: slog.LevelWarn,
+			want:    []slog.Level{slog.LevelWarn, slog.LevelError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandlerWithOptions(
+				slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: tt.kept}),
+				tt.filter,
+				&HandlerOptions{Dropped: slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: tt.dropped})},
+			)
+			var got []slog.Level
+			for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+				if h.Enabled(context.Background(), level) {
+					got = append(got, level)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerSetFilter(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewTextHandler(&buf, nil), mockFilter(true))
+	logger := slog.New(h)
+	derived := logger.With("a", 1).WithGroup("g")
+
+	derived.Info("dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("got: %q, want: empty", buf.String())
+	}
+
+	h.SetFilter(mockLevelFilter(func(l slog.Level) bool { return l < slog.LevelInfo }))
+	if derived.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("got: enabled, want: disabled")
+	}
+	derived.Info("kept")
+	if !strings.Contains(buf.String(), "msg=kept") {
+		t.Errorf("got: %q, want: msg=kept", buf.String())
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				derived.Info("concurrent")
+				h.SetFilter(mockFilter(i%2 == 0))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestInspect(t *testing.T) {
+	a, b := mockFilter(true), mockFilter(false)
+
+	desc, ok := Inspect(Or(a, Not(b)))
+	if !ok {
+		t.Fatal("got: false, want: true")
+	}
+	if desc.Name != "Or" || len(desc.Filters) != 2 {
+		t.Errorf("got: %+v, want: Or with 2 filters", desc)
+	}
+	if desc, _ := Inspect(desc.Filters[1]); desc.Name != "Not" {
+		t.Errorf("got: %q, want: %q", desc.Name, "Not")
+	}
+	if _, ok := Inspect(a); ok {
+		t.Error("got: true, want: false")
+	}
+
+	wrapped := Not(b)
+	if _, ok := Inspect(func(ctx context.Context, r slog.Record) bool { return wrapped(ctx, r) }); ok {
+		t.Error("got: true, want: false")
+	}
+}
+
+func TestOperator(t *testing.T) {
+	a, b := mockFilter(true), mockFilter(false)
+	tests := []struct {
+		filter Filter
+		want   string
+	}{
+		{filter: And(a, b), want: "And"},
+		{filter: Or(a, b), want: "Or"},
+		{filter: Not(a), want: "Not"},
+		{filter: Describe(a, Description{Name: "Not"}), want: ""},
+		{filter: a, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := Operator(tt.filter); got != tt.want {
+			t.Errorf("%s: got: %q, want: %q", tt.filter, got, tt.want)
 		}
-		return ms
 	}
+}
 
-	err := slogtest.TestHandler(h, results)
-	if err != nil {
-		t.Fatal(err)
+func TestFilterString(t *testing.T) {
+	f := And(
+		Not(Or(mockLevelFilter(nil), mockFilter(true))),
+		Describe(mockFilter(true), Description{Name: "IfAttrEquals", Args: []any{"status", 500}}),
+	)
+
+	want := "And(Not(Or(mockLevelFilter(), Filter)), IfAttrEquals(status, 500))"
+	if got := f.String(); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	type attr struct {
+		groups string
+		key    string
+	}
+
+	var got []attr
+	h := NewHandler(
+		slog.NewTextHandler(io.Discard, nil),
+		func(ctx context.Context, r slog.Record) bool {
+			for groups, a := range Attrs(ctx, r) {
+				got = append(got, attr{strings.Join(groups, "."), a.Key})
+			}
+			return false
+		},
+	)
+
+	logger := slog.New(h).With("a", 1).WithGroup("g").With("b", 2).WithGroup("h")
+	logger.Info("message", "c", 3)
+
+	want := []attr{
+		{groups: "", key: "a"},
+		{groups: "g", key: "b"},
+		{groups: "g.h", key: "c"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
 
@@ -254,8 +508,36 @@ func TestNot(t *testing.T) {
 	}
 }
 
+func mockLevelFilter(predicate func(slog.Level) bool) Filter {
+	return Describe(
+		func(_ context.Context, r slog.Record) bool {
+			return predicate(r.Level)
+		},
+		Description{Name: "mockLevelFilter", Level: predicate},
+	)
+}
+
 func mockFilter(result bool) Filter {
 	return func(ctx context.Context, r slog.Record) bool {
 		return result
 	}
 }
+
+// jsonResults returns a function that parses the JSON lines written to the given buffer,
+// for use with [slogtest.TestHandler].
+func jsonResults(t *testing.T, buf *bytes.Buffer) func() []map[string]any {
+	return func() []map[string]any {
+		var ms []map[string]any
+		for line := range bytes.SplitSeq(buf.Bytes(), []byte{'\n'}) {
+			if len(line) == 0 {
+				continue
+			}
+			var m map[string]any
+			if err := json.Unmarshal(line, &m); err != nil {
+				t.Fatal(err)
+			}
+			ms = append(ms, m)
+		}
+		return ms
+	}
+}
@@ -0,0 +1,245 @@
+package slogic
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var _ slog.Handler = (*TailSamplingHandler)(nil)
+
+// NewTailSamplingHandler constructs a [*TailSamplingHandler] that wraps the given handler,
+// buffering the records of each request, as identified by the given key function,
+// until the keep filter returns true for any of them.
+//
+// The key function returns the key of the request that a record belongs to,
+// such as the value of a "request_id" attribute via [go.luke.ph/slogic/filter.ByAttr],
+// or of a context value. Records for which it returns "" are passed to the wrapped handler as-is.
+// The key function can use [Attrs] like a [Filter].
+//
+// A nil opts is equivalent to the zero [TailSamplingOptions].
+func NewTailSamplingHandler(handler slog.Handler, key func(context.Context, slog.Record) string, keep Filter, opts *TailSamplingOptions) *TailSamplingHandler {
+	s := &tailState{
+		key:         key,
+		keep:        keep,
+		maxRequests: defaultMaxRequests,
+		maxRecords:  defaultMaxRecords,
+		timeout:     defaultTimeout,
+		afterFunc:   time.AfterFunc,
+		requests:    make(map[string]*list.Element),
+		order:       list.New(),
+	}
+	if opts != nil {
+		if opts.MaxRequests > 0 {
+			s.maxRequests = opts.MaxRequests
+		}
+		if opts.MaxRecords > 0 {
+			s.maxRecords = opts.MaxRecords
+		}
+		if opts.Timeout > 0 {
+			s.timeout = opts.Timeout
+		}
+	}
+	return &TailSamplingHandler{handler: handler, state: s}
+}
+
+const (
+	defaultMaxRequests = 1024
+	defaultMaxRecords  = 256
+	defaultTimeout     = time.Minute
+)
+
+// TailSamplingOptions are options for a [TailSamplingHandler].
+type TailSamplingOptions struct {
+	// MaxRequests is the maximum number of requests whose records are buffered.
+	// Once exceeded, the oldest request is abandoned.
+	// If zero, it defaults to 1024.
+	MaxRequests int
+
+	// MaxRecords is the maximum number of records buffered per request.
+	// Once exceeded, the request's oldest records are discarded.
+	// If zero, it defaults to 256.
+	MaxRecords int
+
+	// Timeout is the duration after a request's first record after which,
+	// if [TailSamplingHandler.End] has not been called for it and it is not being kept,
+	// the request is abandoned.
+	// If zero, it defaults to 1 minute.
+	Timeout time.Duration
+}
+
+// A TailSamplingHandler implements the [slog.Handler] interface,
+// making sampling decisions per request rather than per record.
+//
+// It buffers the records of each request until the keep filter returns true
+// for one of them, e.g. an error or a slow response, at which point it passes
+// the buffered records, and every subsequent record of the request, to the wrapped handler.
+// Once the request ends, per [TailSamplingHandler.End], the records of requests
+// for which the keep filter never returned true are discarded.
+//
+// Requests that are abandoned, by exceeding [TailSamplingOptions.MaxRequests]
+// or [TailSamplingOptions.Timeout], are treated as if they had ended.
+type TailSamplingHandler struct {
+	handler slog.Handler
+	state   *tailState
+	scope
+}
+
+// tailState holds the requests of a [TailSamplingHandler],
+// shared by the handlers derived from it via WithAttrs and WithGroup.
+type tailState struct {
+	key         func(context.Context, slog.Record) string
+	keep        Filter
+	maxRequests int
+	maxRecords  int
+	timeout     time.Duration
+	afterFunc   func(time.Duration, func()) *time.Timer
+
+	mu       sync.Mutex
+	requests map[string]*list.Element
+	order    *list.List // of *tailRequest, oldest first
+}
+
+type tailRequest struct {
+	key    string
+	kept   bool
+	buffer *ring
+	timer  *time.Timer
+
+	// flushing reports whether the buffered records are being passed to the wrapped handler,
+	// in which case later records are queued behind them so as to preserve their order.
+	flushing bool
+	queued   []bufferedRecord
+}
+
+// Enabled implements the [slog.Handler] Enabled interface method.
+// It calls the wrapped handler's Enabled method.
+func (h *TailSamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements the [slog.Handler] Handle interface method.
+// It calls the wrapped handler's Handle method if the record's request is being kept,
+// and otherwise buffers the record.
+//
+// When the keep filter first returns true for a request, it returns the errors
+// of the wrapped handler's Handle calls for the buffered records joined via [errors.Join],
+// including those of records handled concurrently while they are being passed on.
+func (h *TailSamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	s := h.state
+	scoped := h.ctx(ctx)
+	key := s.key(scoped, r)
+	if key == "" {
+		return h.handler.Handle(ctx, r)
+	}
+	keep := s.keep(scoped, r)
+
+	s.mu.Lock()
+	req := s.request(key)
+	switch {
+	case req.flushing:
+		req.queued = append(req.queued, newBufferedRecord(ctx, h.handler, r))
+		s.mu.Unlock()
+		return nil
+	case req.kept:
+		s.mu.Unlock()
+		return h.handler.Handle(ctx, r)
+	case !keep:
+		req.buffer.push(newBufferedRecord(ctx, h.handler, r))
+		s.mu.Unlock()
+		return nil
+	}
+	req.kept, req.flushing = true, true
+	req.timer.Stop()
+	pending := append(req.buffer.drain(), bufferedRecord{ctx: ctx, handler: h.handler, record: r})
+
+	var errs []error
+	for len(pending) > 0 {
+		s.mu.Unlock()
+		for _, br := range pending {
+			if err := br.handler.Handle(br.ctx, br.record); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		s.mu.Lock()
+		pending, req.queued = req.queued, nil
+	}
+	req.flushing = false
+	s.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// request returns the request with the given key, starting it if necessary.
+// s.mu must be held.
+func (s *tailState) request(key string) *tailRequest {
+	if e, ok := s.requests[key]; ok {
+		return e.Value.(*tailRequest)
+	}
+	if s.order.Len() >= s.maxRequests {
+		s.end(s.order.Front().Value.(*tailRequest))
+	}
+	req := &tailRequest{
+		key:    key,
+		buffer: &ring{size: s.maxRecords},
+	}
+	req.timer = s.afterFunc(s.timeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if e, ok := s.requests[key]; ok && e.Value == req && !req.kept {
+			s.end(req)
+		}
+	})
+	s.requests[key] = s.order.PushBack(req)
+	return req
+}
+
+// end forgets the given request, discarding its buffered records.
+// s.mu must be held.
+func (s *tailState) end(req *tailRequest) {
+	req.timer.Stop()
+	s.order.Remove(s.requests[req.key])
+	delete(s.requests, req.key)
+}
+
+// End ends the request with the given key, discarding its buffered records
+// unless the keep filter returned true for any of the request's records.
+// It should be called once the request is complete, e.g. by deferring it in an [net/http.Handler].
+//
+// Records of the request handled after End are buffered as those of a new request.
+func (h *TailSamplingHandler) End(key string) {
+	s := h.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.requests[key]; ok {
+		s.end(e.Value.(*tailRequest))
+	}
+}
+
+// WithAttrs implements the [slog.Handler] WithAttrs interface method.
+// It calls the wrapped handler's WithAttrs method,
+// and retains the attributes so that they are visible to the key function and filter via [Attrs].
+func (h *TailSamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.handler = h.handler.WithAttrs(attrs)
+	h2.scope = h.with(groupOrAttrs{attrs: attrs})
+	return &h2
+}
+
+// WithGroup implements the [slog.Handler] WithGroup interface method.
+// It calls the wrapped handler's WithGroup method,
+// and retains the group so that it is visible to the key function and filter via [Attrs].
+func (h *TailSamplingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.handler = h.handler.WithGroup(name)
+	h2.scope = h.with(groupOrAttrs{group: name})
+	return &h2
+}
@@ -0,0 +1,154 @@
+package slogic
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+type requestIDKey struct{}
+
+// requestID is a key function that returns the "request_id" attribute,
+// or else the request ID carried by the context.
+func requestID(ctx context.Context, r slog.Record) string {
+	for groups, attr := range Attrs(ctx, r) {
+		if len(groups) == 0 && attr.Key == "request_id" {
+			return attr.Value.String()
+		}
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func TestTailSamplingHandlerHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewTailSamplingHandler(slog.NewJSONHandler(&buf, nil), requestID, mockFilter(false), nil)
+
+	err := slogtest.TestHandler(h, jsonResults(t, &buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTailSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewTailSamplingHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}),
+		requestID,
+		mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelError }),
+		&TailSamplingOptions{MaxRequests: 4, MaxRecords: 2},
+	)
+	var expire []func()
+	h.state.afterFunc = func(d time.Duration, f func()) *time.Timer {
+		if d != time.Minute {
+			t.Errorf("got: %v, want: %v", d, time.Minute)
+		}
+		expire = append(expire, f)
+		return time.NewTimer(d)
+	}
+
+	logger := slog.New(h)
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "c")
+
+	logger.Info("a1", "request_id", "a")
+	logger.With("request_id", "b").Info("b1")
+	logger.InfoContext(ctx, "c1")
+	logger.Info("a2", "request_id", "a")
+	logger.Info("a3", "request_id", "a")
+	logger.Info("none") // Unbuffered
+	logger.ErrorContext(ctx, "c2")
+	expire[2]()                   // Ignored, as c is being kept
+	logger.InfoContext(ctx, "c3") // Unbuffered, as c is being kept
+	h.End("c")
+	logger.InfoContext(ctx, "c4") // Buffered, as c ended
+	logger.Error("d1", "request_id", "d")
+	logger.Error("a4", "request_id", "a") // Only a2 and a3 remain, as a exceeded MaxRecords
+	expire[1]()
+	logger.Error("b2", "request_id", "b") // b1 was discarded, as b timed out
+	logger.Info("e1", "request_id", "e")  // Abandons a, which is the oldest
+	logger.Info("f1", "request_id", "f")  // Abandons c, discarding c4
+	logger.ErrorContext(ctx, "c5")
+
+	want := "msg=none\n" +
+		"msg=c1\n" +
+		"msg=c2\n" +
+		"msg=c3\n" +
+		"msg=d1 request_id=d\n" +
+		"msg=a2 request_id=a\n" +
+		"msg=a3 request_id=a\n" +
+		"msg=a4 request_id=a\n" +
+		"msg=b2 request_id=b\n" +
+		"msg=c5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if got, want := len(h.state.requests), 4; got != want {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestTailSamplingHandlerConcurrentFlush(t *testing.T) {
+	var buf bytes.Buffer
+	flushing, resume := make(chan struct{}), make(chan struct{})
+	h := NewTailSamplingHandler(
+		blockingHandler{
+			Handler: slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && a.Key != slog.MessageKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}),
+			block: func(r slog.Record) {
+				if r.Message == "1" {
+					close(flushing)
+					<-resume
+				}
+			},
+		},
+		requestID,
+		mockLevelFilter(func(l slog.Level) bool { return l >= slog.LevelError }),
+		nil,
+	)
+	logger := slog.New(h)
+
+	logger.Info("1", "request_id", "a")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.Error("2", "request_id", "a")
+	}()
+
+	// Records handled while the buffered ones are being passed on are queued behind them.
+	<-flushing
+	logger.Info("3", "request_id", "a")
+	close(resume)
+	<-done
+	logger.Info("4", "request_id", "a")
+
+	if got, want := buf.String(), "msg=1\nmsg=2\nmsg=3\nmsg=4\n"; got != want {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+}
+
+// blockingHandler is a [slog.Handler] that calls block before handling each record.
+type blockingHandler struct {
+	slog.Handler
+	block func(slog.Record)
+}
+
+func (h blockingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.block(r)
+	return h.Handler.Handle(ctx, r)
+}